@@ -32,6 +32,10 @@ func main() {
 	rootCmd.PersistentFlags().StringP("output", "o", "", "output directory")
 	rootCmd.PersistentFlags().StringP("package", "p", "", "package name for generated code")
 	rootCmd.PersistentFlags().Bool("with-tests", true, "generate tests")
+	rootCmd.PersistentFlags().String("templates", "", "directory of templates overriding the built-in ones")
+	rootCmd.PersistentFlags().Bool("force", false, "overwrite files in the output directory that were not generated")
+
+	rootCmd.AddCommand(newTemplatesCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -64,6 +68,12 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	if withTests, _ := cmd.Flags().GetBool("with-tests"); !withTests {
 		cfg.Testing.Generate = false
 	}
+	if templatesDir, _ := cmd.Flags().GetString("templates"); templatesDir != "" {
+		cfg.TemplatesDir = templatesDir
+	}
+	if force, _ := cmd.Flags().GetBool("force"); force {
+		cfg.Generator.Force = true
+	}
 
 	// Initialize generator
 	gen, err := generator.New(cfg)
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chashtager/opensdkraft/internal/utils"
+	"github.com/chashtager/opensdkraft/templates"
+	"github.com/spf13/cobra"
+)
+
+func newTemplatesCmd() *cobra.Command {
+	templatesCmd := &cobra.Command{
+		Use:   "templates",
+		Short: "Manage the templates used for generation",
+	}
+
+	exportCmd := &cobra.Command{
+		Use:   "export [dir]",
+		Short: "Write the built-in templates to a directory for customization",
+		Long: `Write the built-in templates to a directory (default ./templates) so
+they can be edited and passed back with --templates or templatesDir.
+
+With --diff nothing is written; instead the templates in the directory are
+compared with the built-in templates of this sdkraft version.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runTemplatesExport,
+	}
+	exportCmd.Flags().Bool("diff", false, "show how the templates in dir differ from the built-in ones")
+	exportCmd.Flags().Bool("force", false, "overwrite templates that already exist in dir")
+
+	templatesCmd.AddCommand(exportCmd)
+	return templatesCmd
+}
+
+func runTemplatesExport(cmd *cobra.Command, args []string) error {
+	dir := "templates"
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	if diff, _ := cmd.Flags().GetBool("diff"); diff {
+		return diffTemplates(cmd, dir)
+	}
+
+	force, _ := cmd.Flags().GetBool("force")
+	return exportTemplates(cmd, dir, force)
+}
+
+// exportTemplates writes every built-in template to dir. Existing files are
+// only replaced with force, so customized templates are not lost.
+func exportTemplates(cmd *cobra.Command, dir string, force bool) error {
+	names, err := builtInTemplates()
+	if err != nil {
+		return err
+	}
+
+	if !force {
+		var existing []string
+		for _, name := range names {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				existing = append(existing, name)
+			}
+		}
+		if len(existing) > 0 {
+			return fmt.Errorf("templates already exist in %s: %s (use --force to overwrite)",
+				dir, strings.Join(existing, ", "))
+		}
+	}
+
+	for _, name := range names {
+		content, err := fs.ReadFile(templates.FS, name)
+		if err != nil {
+			return fmt.Errorf("failed to read built-in template %s: %w", name, err)
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := utils.CreateDirectory(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+		if err := utils.WriteFile(path, content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Exported %d templates to %s\n", len(names), dir)
+	return nil
+}
+
+// diffTemplates prints a unified diff for every template in dir that differs
+// from its built-in version, and lists templates that have no built-in
+// counterpart.
+func diffTemplates(cmd *cobra.Command, dir string) error {
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("template directory %s is not accessible: %w", dir, err)
+	}
+
+	out := cmd.OutOrStdout()
+	userFS := os.DirFS(dir)
+	changed := 0
+
+	err := fs.WalkDir(userFS, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(name, ".tmpl") {
+			return nil
+		}
+
+		custom, err := fs.ReadFile(userFS, name)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+
+		builtIn, err := fs.ReadFile(templates.FS, name)
+		if err != nil {
+			fmt.Fprintf(out, "Only in %s: %s\n", dir, name)
+			changed++
+			return nil
+		}
+
+		if diff := utils.UnifiedDiff("built-in/"+name, filepath.ToSlash(filepath.Join(dir, name)), string(builtIn), string(custom)); diff != "" {
+			fmt.Fprint(out, diff)
+			changed++
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to compare templates: %w", err)
+	}
+
+	if changed == 0 {
+		fmt.Fprintf(out, "Templates in %s match the built-in templates\n", dir)
+	}
+	return nil
+}
+
+// builtInTemplates returns the paths of all embedded templates
+func builtInTemplates() ([]string, error) {
+	var names []string
+	err := fs.WalkDir(templates.FS, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(name, ".tmpl") {
+			names = append(names, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list built-in templates: %w", err)
+	}
+	return names, nil
+}
@@ -10,23 +10,49 @@ import (
 
 type Config struct {
 	SDKName       string               `yaml:"sdkName"`
+	SDKVersion    string               `yaml:"sdkVersion"` // defaults to info.version of the spec
 	OutputDir     string               `yaml:"outputDir"`
 	PackageName   string               `yaml:"packageName"`
 	Module        string               `yaml:"module"`
+	TemplatesDir  string               `yaml:"templatesDir"` // overrides built-in templates by name
 	CodeStyle     CodeStyle            `yaml:"codeStyle"`
 	Generator     GeneratorOptions     `yaml:"generator"`
 	Testing       Testing              `yaml:"testing"`
+	Validation    ValidationOptions    `yaml:"validation"`
 	Documentation DocumentationOptions `yaml:"documentation"`
 }
 
 type CodeStyle struct {
 	UsePointers   bool              `yaml:"usePointers"`
+	NullableStyle string            `yaml:"nullableStyle"` // "generic" or "pointer"
 	IndentStyle   string            `yaml:"indentStyle"`
 	MaxLineLength int               `yaml:"maxLineLength"`
 	Comments      bool              `yaml:"generateComments"`
 	Formatting    FormattingOptions `yaml:"formatting"`
+
+	// SourceComments precedes generated types, fields and methods with a
+	// "// source:" comment locating the spec element they come from
+	SourceComments bool `yaml:"sourceComments"`
 }
 
+// Supported values of CodeStyle.NullableStyle
+const (
+	// NullableStyleGeneric generates nullable fields as Nullable[T], which
+	// tells an absent field apart from an explicit null
+	NullableStyleGeneric = "generic"
+	// NullableStylePointer generates nullable fields as plain pointers
+	NullableStylePointer = "pointer"
+)
+
+// Supported values of GeneratorOptions.Layout
+const (
+	// LayoutPackages generates the models into a models package imported by
+	// the client package
+	LayoutPackages = "packages"
+	// LayoutFlat generates the whole SDK into a single package
+	LayoutFlat = "flat"
+)
+
 type Testing struct {
 	Generate  bool            `yaml:"generate"`
 	Framework string          `yaml:"framework"` // e.g., "testify", "standard"
@@ -35,6 +61,24 @@ type Testing struct {
 	Coverage  CoverageOptions `yaml:"coverage"`
 }
 
+// ValidationOptions select the checks run on the generated code
+type ValidationOptions struct {
+	// TypeCheck type-checks the generated packages like go build would
+	TypeCheck bool       `yaml:"typeCheck"`
+	Style     StyleRules `yaml:"style"`
+}
+
+// StyleRules toggle the style rules applied to every generated file
+type StyleRules struct {
+	PackageNames   bool `yaml:"packageNames"`   // lowercase single-word package names
+	Imports        bool `yaml:"imports"`        // no duplicate or malformed import paths
+	ExportedNames  bool `yaml:"exportedNames"`  // exported types, fields and functions in PascalCase
+	JSONTags       bool `yaml:"jsonTags"`       // struct tags carry a well-formed json tag
+	JSONFieldNames bool `yaml:"jsonFieldNames"` // json names in lowercase, unlike e.g. photoUrls
+	ReceiverNames  bool `yaml:"receiverNames"`  // receiver names of 1-3 characters
+	ParamNames     bool `yaml:"paramNames"`     // short camelCase parameter and result names
+}
+
 type FormattingOptions struct {
 	TabWidth       int    `yaml:"tabWidth"`
 	UseSpaces      bool   `yaml:"useSpaces"`
@@ -58,7 +102,11 @@ type GeneratorOptions struct {
 	IncludeValidation  bool          `yaml:"includeValidation"`
 	GenerateInterfaces bool          `yaml:"generateInterfaces"`
 	IncludeJSON        bool          `yaml:"includeJSON"`
+	StrictEnums        bool          `yaml:"strictEnums"`
+	Layout             string        `yaml:"layout"` // "packages" or "flat"
 	Verbose            bool          `yaml:"verbose"`
+	Force              bool          `yaml:"force"`       // overwrite files not listed in the manifest
+	KeepRegions        bool          `yaml:"keepRegions"` // empty keep regions for hand-written code
 	ClientOptions      ClientOptions `yaml:"clientOptions"`
 }
 
@@ -91,9 +139,20 @@ func LoadConfig(configPath string) (*Config, error) {
 	v.SetDefault("sdkName", "generatedSDK")
 	v.SetDefault("outputDir", "./generated")
 	v.SetDefault("codeStyle.usePointers", true)
+	v.SetDefault("codeStyle.nullableStyle", NullableStyleGeneric)
 	v.SetDefault("codeStyle.indentStyle", "space")
 	v.SetDefault("codeStyle.maxLineLength", 120)
 	v.SetDefault("codeStyle.generateComments", true)
+	v.SetDefault("codeStyle.sourceComments", false)
+	v.SetDefault("generator.clientOptions.retryBackoffSeconds", 1)
+	v.SetDefault("generator.layout", LayoutPackages)
+	v.SetDefault("validation.typeCheck", true)
+	v.SetDefault("validation.style.packageNames", true)
+	v.SetDefault("validation.style.imports", true)
+	v.SetDefault("validation.style.exportedNames", true)
+	v.SetDefault("validation.style.jsonTags", true)
+	v.SetDefault("validation.style.receiverNames", true)
+	v.SetDefault("validation.style.paramNames", true)
 
 	if configPath != "" {
 		v.SetConfigFile(configPath)
@@ -143,6 +202,7 @@ func (c *Config) validatePaths() error {
 
 	// Expand environment variables
 	c.OutputDir = os.ExpandEnv(c.OutputDir)
+	c.TemplatesDir = os.ExpandEnv(c.TemplatesDir)
 
 	return nil
 }
@@ -156,6 +216,18 @@ func (c *Config) validateGenerator() error {
 		return fmt.Errorf("max retries cannot be negative")
 	}
 
+	if c.Generator.ClientOptions.RetryBackoffSeconds < 0 {
+		return fmt.Errorf("retry backoff cannot be negative")
+	}
+
+	if c.Generator.Layout == "" {
+		c.Generator.Layout = LayoutPackages
+	}
+
+	if c.Generator.Layout != LayoutPackages && c.Generator.Layout != LayoutFlat {
+		return fmt.Errorf("layout must be either '%s' or '%s'", LayoutPackages, LayoutFlat)
+	}
+
 	return nil
 }
 
@@ -182,6 +254,14 @@ func (c *Config) validateCodeStyle() error {
 		return fmt.Errorf("indent style must be either 'tab' or 'space'")
 	}
 
+	if c.CodeStyle.NullableStyle == "" {
+		c.CodeStyle.NullableStyle = NullableStyleGeneric
+	}
+
+	if c.CodeStyle.NullableStyle != NullableStyleGeneric && c.CodeStyle.NullableStyle != NullableStylePointer {
+		return fmt.Errorf("nullable style must be either '%s' or '%s'", NullableStyleGeneric, NullableStylePointer)
+	}
+
 	return nil
 }
 
@@ -12,67 +12,82 @@ import (
 	"github.com/chashtager/opensdkraft/internal/errors"
 )
 
+// CodeValidator applies the style rules enabled in the configuration to
+// generated Go files
 type CodeValidator struct {
-	config *config.Config
+	rules config.StyleRules
 }
 
 func NewCodeValidator(config *config.Config) *CodeValidator {
 	return &CodeValidator{
-		config: config,
+		rules: config.Validation.Style,
 	}
 }
 
 func (v *CodeValidator) ValidateGoCode(filename string, content []byte) error {
+	issues, err := v.Check(filename, content)
+	if err != nil {
+		return err
+	}
+
+	if len(issues) > 0 {
+		return errors.InvalidInput(fmt.Sprintf(
+			"code validation failed:\n%s", strings.Join(issues, "\n")))
+	}
+
+	return nil
+}
+
+// Check parses a Go file and returns the violations of the enabled style
+// rules, each prefixed with its file:line:column position
+func (v *CodeValidator) Check(filename string, content []byte) ([]string, error) {
 	fset := token.NewFileSet()
 	file, err := parser.ParseFile(fset, filename, content, parser.AllErrors)
 	if err != nil {
-		return errors.Wrap(errors.ErrCodeValidationFailed,
+		return nil, errors.Wrap(errors.ErrCodeValidationFailed,
 			fmt.Sprintf("failed to parse Go file %s", filename), err)
 	}
 
-	var validationErrors []string
+	var issues []string
+	report := func(node ast.Node, format string, args ...interface{}) {
+		issues = append(issues, fmt.Sprintf("%s: %s", fset.Position(node.Pos()), fmt.Sprintf(format, args...)))
+	}
 
 	// Check package name
-	if !v.isValidPackageName(file.Name.Name) {
-		validationErrors = append(validationErrors,
-			fmt.Sprintf("invalid package name: %s", file.Name.Name))
+	if v.rules.PackageNames && !v.isValidPackageName(file.Name.Name) {
+		report(file.Name, "invalid package name: %s", file.Name.Name)
 	}
 
 	// Check imports
-	if err := v.validateImports(file.Imports); err != nil {
-		validationErrors = append(validationErrors, err.Error())
+	if v.rules.Imports {
+		v.validateImports(file.Imports, report)
 	}
 
 	// Check declarations
 	ast.Inspect(file, func(n ast.Node) bool {
 		switch x := n.(type) {
 		case *ast.TypeSpec:
-			if err := v.validateTypeSpec(x); err != nil {
-				validationErrors = append(validationErrors, err.Error())
-			}
+			v.validateTypeSpec(x, report)
 		case *ast.FuncDecl:
-			if err := v.validateFuncDecl(x); err != nil {
-				validationErrors = append(validationErrors, err.Error())
-			}
+			v.validateFuncDecl(x, report)
 		}
 		return true
 	})
 
-	if len(validationErrors) > 0 {
-		return errors.InvalidInput(fmt.Sprintf(
-			"code validation failed:\n%s", strings.Join(validationErrors, "\n")))
-	}
-
-	return nil
+	return issues, nil
 }
 
+// reporter records a style violation at the position of a node
+type reporter func(node ast.Node, format string, args ...interface{})
+
 func (v *CodeValidator) isValidPackageName(name string) bool {
-	// Package names should be all lowercase and a single word
+	// Package names should be all lowercase and a single word. External
+	// test packages add _test.
+	name = strings.TrimSuffix(name, "_test")
 	return name == strings.ToLower(name) && !strings.Contains(name, "_")
 }
 
-func (v *CodeValidator) validateImports(imports []*ast.ImportSpec) error {
-	var validationErrors []string
+func (v *CodeValidator) validateImports(imports []*ast.ImportSpec, report reporter) {
 	seenImports := make(map[string]bool)
 
 	for _, imp := range imports {
@@ -80,25 +95,16 @@ func (v *CodeValidator) validateImports(imports []*ast.ImportSpec) error {
 
 		// Check for duplicate imports
 		if seenImports[path] {
-			validationErrors = append(validationErrors,
-				fmt.Sprintf("duplicate import: %s", path))
+			report(imp, "duplicate import: %s", path)
 			continue
 		}
 		seenImports[path] = true
 
 		// Check import path format
 		if !v.isValidImportPath(path) {
-			validationErrors = append(validationErrors,
-				fmt.Sprintf("invalid import path: %s", path))
+			report(imp, "invalid import path: %s", path)
 		}
 	}
-
-	if len(validationErrors) > 0 {
-		return fmt.Errorf("import validation failed:\n%s",
-			strings.Join(validationErrors, "\n"))
-	}
-
-	return nil
 }
 
 func (v *CodeValidator) isValidImportPath(path string) bool {
@@ -109,49 +115,34 @@ func (v *CodeValidator) isValidImportPath(path string) bool {
 		!strings.HasSuffix(path, "/")
 }
 
-func (v *CodeValidator) validateTypeSpec(spec *ast.TypeSpec) error {
+func (v *CodeValidator) validateTypeSpec(spec *ast.TypeSpec, report reporter) {
 	// Check type name (should be PascalCase for exported types)
-	if spec.Name.IsExported() {
-		if !v.isPascalCase(spec.Name.Name) {
-			return fmt.Errorf("exported type %s should be in PascalCase", spec.Name.Name)
-		}
+	if v.rules.ExportedNames && spec.Name.IsExported() && !v.isPascalCase(spec.Name.Name) {
+		report(spec.Name, "exported type %s should be in PascalCase", spec.Name.Name)
 	}
 
 	// Check struct fields if it's a struct type
 	if structType, ok := spec.Type.(*ast.StructType); ok {
-		return v.validateStructFields(spec.Name.Name, structType.Fields)
+		v.validateStructFields(spec.Name.Name, structType.Fields, report)
 	}
-
-	return nil
 }
 
-func (v *CodeValidator) validateStructFields(structName string, fields *ast.FieldList) error {
-	var validationErrors []string
-
+func (v *CodeValidator) validateStructFields(structName string, fields *ast.FieldList, report reporter) {
 	for _, field := range fields.List {
 		for _, name := range field.Names {
 			// Check field name (should be PascalCase for exported fields)
-			if name.IsExported() && !v.isPascalCase(name.Name) {
-				validationErrors = append(validationErrors,
-					fmt.Sprintf("exported field %s.%s should be in PascalCase",
-						structName, name.Name))
+			if v.rules.ExportedNames && name.IsExported() && !v.isPascalCase(name.Name) {
+				report(name, "exported field %s.%s should be in PascalCase", structName, name.Name)
 			}
 		}
 
 		// Check tags if present
 		if field.Tag != nil {
 			if err := v.validateStructTag(field.Tag.Value); err != nil {
-				validationErrors = append(validationErrors, err.Error())
+				report(field.Tag, "%v", err)
 			}
 		}
 	}
-
-	if len(validationErrors) > 0 {
-		return fmt.Errorf("struct validation failed:\n%s",
-			strings.Join(validationErrors, "\n"))
-	}
-
-	return nil
 }
 
 func (v *CodeValidator) validateStructTag(tag string) error {
@@ -159,47 +150,31 @@ func (v *CodeValidator) validateStructTag(tag string) error {
 	tag = strings.Trim(tag, "`")
 
 	// Check common tag formats
-	if !strings.Contains(tag, "json:\"") {
-		return fmt.Errorf("missing json tag")
-	}
-
-	// Validate json tag format
-	if !v.isValidJSONTag(tag) {
-		return fmt.Errorf("invalid json tag format: %s", tag)
-	}
-
-	return nil
-}
-
-func (v *CodeValidator) isValidJSONTag(tag string) bool {
-	// Basic json tag validation
-	parts := strings.Split(tag, "json:\"")
-	if len(parts) != 2 {
-		return false
+	_, jsonTag, found := strings.Cut(tag, "json:\"")
+	if !found {
+		if v.rules.JSONTags {
+			return fmt.Errorf("missing json tag")
+		}
+		return nil
 	}
-
-	tagValue := strings.Split(parts[1], "\"")[0]
+	tagValue, _, _ := strings.Cut(jsonTag, "\"")
 	tagParts := strings.Split(tagValue, ",")
 
 	// Check if the field name part is valid
-	if len(tagParts) > 0 {
-		fieldName := tagParts[0]
-		if fieldName != "-" && !v.isValidJSONFieldName(fieldName) {
-			return false
-		}
+	if fieldName := tagParts[0]; v.rules.JSONFieldNames && fieldName != "-" && !v.isValidJSONFieldName(fieldName) {
+		return fmt.Errorf("json field name %s should be lowercase", fieldName)
 	}
 
 	// Check tag options
-	for i, opt := range tagParts {
-		if i == 0 {
-			continue
-		}
-		if opt != "omitempty" && opt != "string" {
-			return false
+	if v.rules.JSONTags {
+		for _, opt := range tagParts[1:] {
+			if opt != "omitempty" && opt != "string" {
+				return fmt.Errorf("invalid json tag format: %s", tag)
+			}
 		}
 	}
 
-	return true
+	return nil
 }
 
 func (v *CodeValidator) isValidJSONFieldName(name string) bool {
@@ -212,96 +187,59 @@ func (v *CodeValidator) isValidJSONFieldName(name string) bool {
 	return true
 }
 
-func (v *CodeValidator) validateFuncDecl(decl *ast.FuncDecl) error {
-	var validationErrors []string
-
+func (v *CodeValidator) validateFuncDecl(decl *ast.FuncDecl, report reporter) {
 	// Check function name
-	if decl.Name.IsExported() {
-		if !v.isPascalCase(decl.Name.Name) {
-			validationErrors = append(validationErrors,
-				fmt.Sprintf("exported function %s should be in PascalCase", decl.Name.Name))
-		}
+	if v.rules.ExportedNames && decl.Name.IsExported() && !v.isPascalCase(decl.Name.Name) {
+		report(decl.Name, "exported function %s should be in PascalCase", decl.Name.Name)
 	}
 
 	// Check receiver if it's a method
-	if decl.Recv != nil && len(decl.Recv.List) > 0 {
+	if v.rules.ReceiverNames && decl.Recv != nil && len(decl.Recv.List) > 0 {
 		recv := decl.Recv.List[0]
 		if len(recv.Names) > 0 {
-			receiverName := recv.Names[0].Name
-			if len(receiverName) > 3 {
-				validationErrors = append(validationErrors,
-					fmt.Sprintf("receiver name %s is too long, should be 1-3 characters", receiverName))
+			receiverName := recv.Names[0]
+			if len(receiverName.Name) > 3 {
+				report(receiverName, "receiver name %s is too long, should be 1-3 characters", receiverName.Name)
 			}
 		}
 	}
 
-	// Check parameters
-	if err := v.validateFuncParams(decl.Type.Params); err != nil {
-		validationErrors = append(validationErrors, err.Error())
-	}
-
-	// Check return values
-	if err := v.validateFuncResults(decl.Type.Results); err != nil {
-		validationErrors = append(validationErrors, err.Error())
-	}
+	if v.rules.ParamNames {
+		// Check parameters
+		v.validateFuncParams(decl.Type.Params, report)
 
-	if len(validationErrors) > 0 {
-		return fmt.Errorf("function validation failed:\n%s",
-			strings.Join(validationErrors, "\n"))
+		// Check return values
+		v.validateFuncResults(decl.Type.Results, report)
 	}
-
-	return nil
 }
 
-func (v *CodeValidator) validateFuncParams(fields *ast.FieldList) error {
+func (v *CodeValidator) validateFuncParams(fields *ast.FieldList, report reporter) {
 	if fields == nil {
-		return nil
+		return
 	}
 
-	var validationErrors []string
-
 	for _, field := range fields.List {
 		for _, name := range field.Names {
 			if !v.isValidParamName(name.Name) {
-				validationErrors = append(validationErrors,
-					fmt.Sprintf("invalid parameter name: %s", name.Name))
+				report(name, "invalid parameter name: %s", name.Name)
 			}
 		}
 	}
-
-	if len(validationErrors) > 0 {
-		return fmt.Errorf("parameter validation failed:\n%s",
-			strings.Join(validationErrors, "\n"))
-	}
-
-	return nil
 }
 
-func (v *CodeValidator) validateFuncResults(fields *ast.FieldList) error {
+func (v *CodeValidator) validateFuncResults(fields *ast.FieldList, report reporter) {
 	if fields == nil {
-		return nil
+		return
 	}
 
-	var validationErrors []string
-
 	for _, field := range fields.List {
 		// Unnamed return values are fine
-		if field.Names != nil {
-			for _, name := range field.Names {
-				if !v.isValidResultName(name.Name) {
-					validationErrors = append(validationErrors,
-						fmt.Sprintf("invalid result name: %s", name.Name))
-				}
+		for _, name := range field.Names {
+			if !v.isValidResultName(name.Name) {
+				report(name, "invalid result name: %s", name.Name)
 			}
 		}
 	}
-
-	if len(validationErrors) > 0 {
-		return fmt.Errorf("result validation failed:\n%s",
-			strings.Join(validationErrors, "\n"))
-	}
-
-	return nil
 }
 
 func (v *CodeValidator) isPascalCase(name string) bool {
@@ -0,0 +1,193 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/chashtager/opensdkraft/internal/utils"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ValidationRule is a single generated constraint check. Condition is a Go
+// expression that is true when the constraint is violated.
+type ValidationRule struct {
+	Condition string
+	Message   string
+}
+
+// PatternData is a precompiled regular expression used by a model's Validate
+type PatternData struct {
+	Var     string
+	Pattern string
+}
+
+// Nested validation modes for properties whose type has its own Validate
+const (
+	nestedPointer  = "pointer"
+	nestedValue    = "value"
+	nestedNullable = "nullable"
+	nestedSlice    = "slice"
+	nestedMap      = "map"
+)
+
+// prepareValidation fills in the constraint checks of prop and registers the
+// patterns and imports they need on modelData.
+func (g *ModelGenerator) prepareValidation(modelData *ModelData, prop *PropertyData, schema *openapi3.SchemaRef) error {
+	if !g.config.Generator.IncludeValidation || schema == nil || schema.Value == nil {
+		return nil
+	}
+
+	s := schema.Value
+	field := "m." + prop.Name
+	value := field
+	guard := ""
+
+	// Optional fields are only checked when they are set
+	switch {
+	case isNullableType(prop.Type):
+		guard = field + ".HasValue()"
+		value = field + ".Value()"
+	case strings.HasPrefix(prop.Type, "*"):
+		guard = field + " != nil"
+		value = "*" + field
+	case strings.HasPrefix(prop.Type, "[]"), strings.HasPrefix(prop.Type, "map["):
+		if !prop.Required {
+			guard = field + " != nil"
+		}
+	case !prop.Required && prop.ZeroValue != "nil":
+		guard = field + " != " + prop.ZeroValue
+	}
+
+	addRule := func(condition, message string) {
+		if guard != "" {
+			condition = guard + " && " + condition
+		}
+		prop.Validation = append(prop.Validation, ValidationRule{
+			Condition: condition,
+			Message:   strconv.Quote(message),
+		})
+	}
+
+	switch {
+	case valueType(prop.Type) == "string":
+		if s.MinLength > 0 {
+			addRule(fmt.Sprintf("utf8.RuneCountInString(%s) < %d", value, s.MinLength),
+				fmt.Sprintf("length must be at least %d", s.MinLength))
+			modelData.addImport("unicode/utf8")
+		}
+		if s.MaxLength != nil {
+			addRule(fmt.Sprintf("utf8.RuneCountInString(%s) > %d", value, *s.MaxLength),
+				fmt.Sprintf("length must be at most %d", *s.MaxLength))
+			modelData.addImport("unicode/utf8")
+		}
+		if s.Pattern != "" {
+			if _, err := regexp.Compile(s.Pattern); err != nil {
+				return fmt.Errorf("property %s: pattern %q is not supported: %v", prop.JSONName, s.Pattern, err)
+			}
+			patternVar := utils.ToLowerCamelCase(modelData.Name) + prop.Name + "Pattern"
+			modelData.Patterns = append(modelData.Patterns, PatternData{
+				Var:     patternVar,
+				Pattern: strconv.Quote(s.Pattern),
+			})
+			addRule(fmt.Sprintf("!%s.MatchString(%s)", patternVar, value),
+				fmt.Sprintf("must match pattern %s", s.Pattern))
+			modelData.addImport("regexp")
+		}
+
+	case s.Type.Is("integer") || s.Type.Is("number"):
+		if s.Min != nil {
+			op, desc := "<", "greater than or equal to"
+			if s.ExclusiveMin {
+				op, desc = "<=", "greater than"
+			}
+			addRule(fmt.Sprintf("float64(%s) %s %s", value, op, formatFloat(*s.Min)),
+				fmt.Sprintf("must be %s %s", desc, formatFloat(*s.Min)))
+		}
+		if s.Max != nil {
+			op, desc := ">", "less than or equal to"
+			if s.ExclusiveMax {
+				op, desc = ">=", "less than"
+			}
+			addRule(fmt.Sprintf("float64(%s) %s %s", value, op, formatFloat(*s.Max)),
+				fmt.Sprintf("must be %s %s", desc, formatFloat(*s.Max)))
+		}
+		if s.MultipleOf != nil {
+			addRule(fmt.Sprintf("!isMultipleOf(float64(%s), %s)", value, formatFloat(*s.MultipleOf)),
+				fmt.Sprintf("must be a multiple of %s", formatFloat(*s.MultipleOf)))
+		}
+
+	case s.Type.Is("array"):
+		if s.MinItems > 0 {
+			addRule(fmt.Sprintf("len(%s) < %d", value, s.MinItems),
+				fmt.Sprintf("must contain at least %d items", s.MinItems))
+		}
+		if s.MaxItems != nil {
+			addRule(fmt.Sprintf("len(%s) > %d", value, *s.MaxItems),
+				fmt.Sprintf("must contain at most %d items", *s.MaxItems))
+		}
+		if s.UniqueItems {
+			addRule(fmt.Sprintf("!hasUniqueItems(%s)", value), "items must be unique")
+		}
+	}
+
+	prop.ValueExpr = value
+	prop.ValidateNested = g.nestedValidation(prop, schema)
+	return nil
+}
+
+// nestedValidation reports how the Validate method of a property's own type,
+// or of its elements, should be invoked.
+func (g *ModelGenerator) nestedValidation(prop *PropertyData, schema *openapi3.SchemaRef) string {
+	switch {
+	case g.hasValidateMethod(schema):
+		switch {
+		case isNullableType(prop.Type):
+			return nestedNullable
+		case strings.HasPrefix(prop.Type, "*"):
+			return nestedPointer
+		}
+		return nestedValue
+	case schema.Value.Type.Is("array") && g.hasValidateMethod(schema.Value.Items):
+		return nestedSlice
+	case schema.Value.AdditionalProperties.Schema != nil && g.hasValidateMethod(schema.Value.AdditionalProperties.Schema):
+		return nestedMap
+	}
+	return ""
+}
+
+// hasValidateMethod reports whether the schema is generated as one of our
+// own types, all of which have a Validate method when validation is enabled.
+func (g *ModelGenerator) hasValidateMethod(schema *openapi3.SchemaRef) bool {
+	if schema == nil || schema.Value == nil {
+		return false
+	}
+	if isRefAlias(schema.Value) {
+		return g.hasValidateMethod(schema.Value.AllOf[0])
+	}
+	return g.typeMapper.IsStructType(schema) || isEnum(schema.Value)
+}
+
+// isNullableType reports whether goType is the generated Nullable wrapper
+func isNullableType(goType string) bool {
+	return strings.HasPrefix(goType, "Nullable[")
+}
+
+// valueType strips the pointer or Nullable wrapper from a property type
+func valueType(goType string) string {
+	if isNullableType(goType) {
+		return strings.TrimSuffix(strings.TrimPrefix(goType, "Nullable["), "]")
+	}
+	return strings.TrimPrefix(goType, "*")
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func (m *ModelData) addImport(imp string) {
+	if !utils.StringContains(m.Imports, imp) {
+		m.Imports = append(m.Imports, imp)
+	}
+}
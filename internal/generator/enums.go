@@ -0,0 +1,176 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chashtager/opensdkraft/internal/config"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// EnumData is the template data for a named enum type
+type EnumData struct {
+	Name        string
+	PackageName string
+	Description string
+	BaseType    string
+	Values      []EnumValue
+	Strict      bool
+	Config      *config.Config
+	Source      Source
+}
+
+// EnumValue is a single constant of an enum type
+type EnumValue struct {
+	Name    string
+	Literal string
+}
+
+// isEnum reports whether the schema is an enum that can be generated as a
+// named Go type. Enums of booleans or mixed values keep their base type.
+func isEnum(schema *openapi3.Schema) bool {
+	if len(schema.Enum) == 0 {
+		return false
+	}
+	switch {
+	case schema.Type.Is("string"), schema.Type.Is("integer"), schema.Type.Is("number"):
+		return true
+	}
+	return false
+}
+
+func (g *ModelGenerator) generateEnum(name, pointer string, schema *openapi3.SchemaRef) error {
+	enumData, err := g.prepareEnumData(name, schema)
+	if err != nil {
+		return &ValidationError{
+			Category: "Model",
+			Path:     name,
+			Source:   Source{Pointer: pointer},
+			Errors:   []string{err.Error()},
+		}
+	}
+	enumData.Source = g.sources.source(pointer)
+
+	content, err := g.templates.Execute("enum", enumData)
+	if err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	filename := modelFile(g.config, name)
+	if err := g.output.write(filename, content); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+func (g *ModelGenerator) prepareEnumData(name string, schema *openapi3.SchemaRef) (*EnumData, error) {
+	if schema == nil || schema.Value == nil {
+		return nil, fmt.Errorf("invalid schema for %s", name)
+	}
+
+	enumData := &EnumData{
+		Name:        g.typeMapper.ToGoName(name),
+		PackageName: modelsPackage(g.config),
+		Description: schema.Value.Description,
+		BaseType:    g.typeMapper.EnumBaseType(schema),
+		Strict:      g.config.Generator.StrictEnums,
+		Config:      g.config,
+	}
+
+	seenNames := make(map[string]bool)
+	for _, value := range schema.Value.Enum {
+		// A null entry only marks the enum as nullable
+		if value == nil {
+			continue
+		}
+
+		literal, err := enumLiteral(enumData.BaseType, value)
+		if err != nil {
+			return nil, err
+		}
+
+		constName := enumData.Name + enumConstSuffix(value)
+		for i := 2; seenNames[constName]; i++ {
+			constName = fmt.Sprintf("%s%s%d", enumData.Name, enumConstSuffix(value), i)
+		}
+		seenNames[constName] = true
+
+		enumData.Values = append(enumData.Values, EnumValue{
+			Name:    constName,
+			Literal: literal,
+		})
+	}
+
+	return enumData, nil
+}
+
+// EnumBaseType returns the underlying Go type of an enum schema, or of one
+// referenced through a single allOf, or an empty string if the schema is not
+// generated as an enum type.
+func (tm *TypeMapper) EnumBaseType(schema *openapi3.SchemaRef) string {
+	if schema != nil && schema.Value != nil && isRefAlias(schema.Value) {
+		return tm.EnumBaseType(schema.Value.AllOf[0])
+	}
+	if schema == nil || schema.Value == nil || !isEnum(schema.Value) {
+		return ""
+	}
+	switch {
+	case schema.Value.Type.Is("integer"):
+		if schema.Value.Format == "int32" || schema.Value.Format == "int64" {
+			return schema.Value.Format
+		}
+		return "int"
+	case schema.Value.Type.Is("number"):
+		if schema.Value.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	default:
+		return "string"
+	}
+}
+
+// enumLiteral renders an enum value as a Go constant of the base type
+func enumLiteral(baseType string, value interface{}) (string, error) {
+	if baseType == "string" {
+		s, ok := value.(string)
+		if !ok {
+			return "", fmt.Errorf("enum value %v is not a string", value)
+		}
+		return strconv.Quote(s), nil
+	}
+
+	f, ok := value.(float64)
+	if !ok {
+		return "", fmt.Errorf("enum value %v is not a number", value)
+	}
+	if strings.HasPrefix(baseType, "int") && f != float64(int64(f)) {
+		return "", fmt.Errorf("enum value %v is not an integer", value)
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64), nil
+}
+
+// enumConstSuffix turns an enum value into the PascalCase suffix of its
+// constant name, e.g. "in_stock" becomes "InStock" and -1 becomes "Minus1".
+func enumConstSuffix(value interface{}) string {
+	s := fmt.Sprint(value)
+	if f, ok := value.(float64); ok {
+		s = strconv.FormatFloat(f, 'f', -1, 64)
+		s = strings.Replace(s, "-", "Minus", 1)
+		s = strings.Replace(s, ".", "Point", 1)
+	}
+
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !strings.ContainsRune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789", r)
+	})
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + strings.ToLower(word[1:])
+	}
+
+	if len(words) == 0 {
+		return "Empty"
+	}
+	return strings.Join(words, "")
+}
@@ -7,9 +7,11 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/chashtager/opensdkraft/internal/config"
 	"github.com/chashtager/opensdkraft/internal/parser"
+	"github.com/chashtager/opensdkraft/internal/utils"
 )
 
 type Generator struct {
@@ -20,11 +22,18 @@ type Generator struct {
 	templateEngine *TemplateEngine
 	validator      *Validator
 	codeValidator  *CodeValidator
+	sources        *sourceMap
+	output         *outputWriter
 	logger         *logging.Logger
 }
 
 // New creates a new Generator instance with all required components
 func New(cfg *config.Config) (*Generator, error) {
+	// The SDK is a module of its own, named after its package by default
+	if cfg.Module == "" {
+		cfg.Module = cfg.PackageName
+	}
+
 	// Initialize logger
 	logger, err := logging.NewLogger(
 		filepath.Join(cfg.OutputDir, "generation.log"),
@@ -56,12 +65,18 @@ func New(cfg *config.Config) (*Generator, error) {
 		templateEngine: tmplEngine,
 		validator:      NewValidator(),
 		codeValidator:  NewCodeValidator(cfg),
+		sources:        newSourceMap(cfg.OutputDir),
+		output:         newOutputWriter(cfg.OutputDir, cfg.Generator.Force, logger),
 		logger:         logger,
 	}
 
 	// Initialize model and operation generators
 	g.modelGen = NewModelGenerator(cfg, tmplEngine, logger)
+	g.modelGen.sources = g.sources
+	g.modelGen.output = g.output
 	g.operationGen = NewOperationGenerator(cfg, tmplEngine, logger)
+	g.operationGen.sources = g.sources
+	g.operationGen.output = g.output
 
 	logger.Info("Generator initialized successfully")
 	return g, nil
@@ -105,64 +120,114 @@ func (g *Generator) Generate(inputFile string) error {
 	}
 	g.logger.Info("Successfully parsed OpenAPI document")
 
+	// Index where the elements of the spec are declared, so that generated
+	// code and errors can point back to them
+	if err := g.sources.load(inputFile, doc); err != nil {
+		g.logger.Warn("Failed to index the spec, errors will not be located: %v", err)
+	}
+
 	// Validate document
 	if err := g.validator.ValidateDocument(doc); err != nil {
+		var e *ValidationErrors
+		if errors.As(err, &e) {
+			g.sources.locate(e)
+		}
 		g.logger.Error("OpenAPI document validation failed:\n%v", err)
 		return fmt.Errorf("document validation failed: %w", err)
 	}
 	g.logger.Info("OpenAPI document validation successful")
 
+	if g.config.SDKVersion == "" && doc.Info != nil {
+		g.config.SDKVersion = doc.Info.Version
+	}
+
 	// Create output structure
 	if err := g.createOutputStructure(); err != nil {
 		g.logger.Error("Failed to create output structure: %v", err)
 		return err
 	}
 
+	// Files are only rewritten if they change, and only if they were
+	// generated before
+	if err := g.output.load(); err != nil {
+		g.logger.Error("Failed to load manifest: %v", err)
+		return err
+	}
+
 	var generationErrors ValidationErrors
 
 	// Generate models
 	g.logger.Info("Generating models")
 	if err := g.modelGen.Generate(doc.Components.Schemas); err != nil {
-		var e *ValidationErrors
-		switch {
-		case errors.As(err, &e):
-			generationErrors.Errors = append(generationErrors.Errors, e.Errors...)
-		default:
-			generationErrors.Add("Models", "generation", err.Error())
-		}
+		collectErrors(&generationErrors, "Models", "generation", err)
 	}
 
 	// Generate operations
 	g.logger.Info("Generating operations")
-	if err := g.operationGen.Generate(doc.Paths); err != nil {
-		var e *ValidationErrors
-		switch {
-		case errors.As(err, &e):
-			generationErrors.Errors = append(generationErrors.Errors, e.Errors...)
-		default:
-			generationErrors.Add("Operations", "generation", err.Error())
-		}
+	var securitySchemes openapi3.SecuritySchemes
+	if doc.Components != nil {
+		securitySchemes = doc.Components.SecuritySchemes
+	}
+	if err := g.operationGen.SetSecurity(securitySchemes, doc.Security); err != nil {
+		collectErrors(&generationErrors, "Operations", "security", err)
+	} else if err := g.operationGen.SetRateLimits(doc); err != nil {
+		collectErrors(&generationErrors, "Operations", "rate limits", err)
+	} else if err := g.operationGen.Generate(doc.Paths); err != nil {
+		collectErrors(&generationErrors, "Operations", "generation", err)
+	}
+	if err := g.modelGen.GenerateInline(g.operationGen.InlineTypes()); err != nil {
+		collectErrors(&generationErrors, "Models", "inline", err)
+	}
+
+	g.logger.Info("Generating go.mod")
+	if err := g.generateModuleFiles(); err != nil {
+		generationErrors.Add("Module", "go.mod", err.Error())
 	}
 
 	// Generate tests if enabled
 	if g.config.Testing.Generate {
 		g.logger.Info("Generating tests")
-		if err := g.generateAndValidateTests(g.operationGen.GetOperations()); err != nil {
+		if err := g.generateTests(g.operationGen.GetOperations()); err != nil {
+			generationErrors.Add("Tests", "generation", err.Error())
+		}
+	}
+
+	// Files that are no longer generated are only known once everything
+	// has been
+	if err := g.output.finish(len(generationErrors.Errors) == 0); err != nil {
+		generationErrors.Add("Output", manifestFile, err.Error())
+	}
+
+	// Problems in the code are only meaningful if everything has been
+	// generated
+	if len(generationErrors.Errors) == 0 {
+		g.logger.Info("Validating generated code")
+		if err := g.validateGeneratedFiles(g.config.OutputDir); err != nil {
+			collectErrors(&generationErrors, "Style", "check", err)
+		}
+	}
+
+	if len(generationErrors.Errors) == 0 && g.config.Validation.TypeCheck {
+		g.logger.Info("Type-checking generated code")
+		if err := g.typeCheck(); err != nil {
 			var e *ValidationErrors
 			switch {
 			case errors.As(err, &e):
 				generationErrors.Errors = append(generationErrors.Errors, e.Errors...)
+			case errors.Is(err, errTypeCheckUnavailable):
+				g.logger.Warn("Skipping type check: %v", err)
 			default:
-				generationErrors.Add("Tests", "generation", err.Error())
+				generationErrors.Add("Types", "check", err.Error())
 			}
 		}
 	}
 
 	// Report any generation errors
 	if len(generationErrors.Errors) > 0 {
+		g.sources.locate(&generationErrors)
 		g.logger.Error("Generation completed with errors:")
 		for _, err := range generationErrors.Errors {
-			g.logger.Error("%s validation errors for %s:", err.Category, err.Path)
+			g.logger.Error("%s validation errors for %s:", err.Category, err.subject())
 			for _, msg := range err.Errors {
 				g.logger.Error("  - %s", msg)
 			}
@@ -174,39 +239,44 @@ func (g *Generator) Generate(inputFile string) error {
 	return nil
 }
 
+// collectErrors adds err to errs, keeping the details of validation errors.
+// Other errors are added under category and path.
+func collectErrors(errs *ValidationErrors, category, path string, err error) {
+	var validationErrors *ValidationErrors
+	var validationError *ValidationError
+	switch {
+	case errors.As(err, &validationErrors):
+		errs.Errors = append(errs.Errors, validationErrors.Errors...)
+	case errors.As(err, &validationError):
+		errs.Errors = append(errs.Errors, *validationError)
+	default:
+		errs.Add(category, path, err.Error())
+	}
+}
+
 func (g *Generator) generateAndValidateModels(schemas openapi3.Schemas) error {
-	modelsDir := filepath.Join(g.config.OutputDir, "models")
 	if err := g.modelGen.Generate(schemas); err != nil {
 		return fmt.Errorf("failed to generate models: %w", err)
 	}
 
 	// Validate all generated model files
-	return g.validateGeneratedFiles(modelsDir)
+	return g.validateGeneratedFiles(modelsDir(g.config))
 }
 
-func (g *Generator) generateAndValidateOperations(paths openapi3.Paths) error {
-	operationsDir := filepath.Join(g.config.OutputDir, "operations")
-	if err := g.operationGen.Generate(&paths); err != nil {
-		return fmt.Errorf("failed to generate operations: %w", err)
-	}
-
-	// Validate all generated operation files
-	return g.validateGeneratedFiles(operationsDir)
-}
-
-func (g *Generator) generateAndValidateTests(operations []*Operation) error {
-	testsDir := filepath.Join(g.config.OutputDir, "tests")
+func (g *Generator) generateTests(operations []*Operation) error {
 	testGen := NewTestGenerator(g.config, g.templateEngine, g.parser)
-	if err := testGen.Generate(operations); err != nil {
+	testGen.sources = g.sources
+	testGen.output = g.output
+	if err := testGen.Generate(operations, g.operationGen.SecuritySchemes()); err != nil {
 		return fmt.Errorf("failed to generate tests: %w", err)
 	}
-
-	// Validate all generated test files
-	return g.validateGeneratedFiles(testsDir)
+	return nil
 }
 
+// validateGeneratedFiles applies the enabled style rules to the Go files in
+// dir, reporting syntax errors and rule violations
 func (g *Generator) validateGeneratedFiles(dir string) error {
-	var validationErrors []error
+	issues := make(map[string][]string)
 
 	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -219,8 +289,13 @@ func (g *Generator) validateGeneratedFiles(dir string) error {
 				return fmt.Errorf("failed to read file %s: %w", path, err)
 			}
 
-			if err := g.codeValidator.ValidateGoCode(path, content); err != nil {
-				validationErrors = append(validationErrors, fmt.Errorf("validation failed for %s: %w", path, err))
+			filename := g.sources.relative(path)
+			fileIssues, err := g.codeValidator.Check(filename, content)
+			if err != nil {
+				fileIssues = []string{err.Error()}
+			}
+			if len(fileIssues) > 0 {
+				issues[filename] = fileIssues
 			}
 		}
 
@@ -231,23 +306,42 @@ func (g *Generator) validateGeneratedFiles(dir string) error {
 		return err
 	}
 
-	if len(validationErrors) > 0 {
-		return fmt.Errorf("validation failed with %d errors", len(validationErrors))
+	var validationErrors ValidationErrors
+	g.reportCodeIssues(&validationErrors, "Style", issues)
+	if len(validationErrors.Errors) > 0 {
+		return &validationErrors
 	}
 
 	return nil
 }
 
+// reportCodeIssues adds problems found in generated files to errs. They are
+// grouped by the element of the spec each file was generated from, if it
+// was generated from a single one, and by file otherwise.
+func (g *Generator) reportCodeIssues(errs *ValidationErrors, category string, issues map[string][]string) {
+	groups := make(map[string][]string)
+	for _, filename := range utils.SortedKeys(issues) {
+		group := g.sources.lookup(filename)
+		if group == "" {
+			group = filename
+		}
+		groups[group] = append(groups[group], issues[filename]...)
+	}
+
+	for _, group := range utils.SortedKeys(groups) {
+		pointer := ""
+		if strings.HasPrefix(group, "#") {
+			pointer = group
+		}
+		errs.AddSource(category, group, pointer, groups[group]...)
+	}
+}
+
 // createOutputStructure creates the necessary directory structure for generated code
 func (g *Generator) createOutputStructure() error {
 	dirs := []string{
 		g.config.OutputDir,
-		filepath.Join(g.config.OutputDir, "models"),
-		filepath.Join(g.config.OutputDir, "operations"),
-	}
-
-	if g.config.Testing.Generate {
-		dirs = append(dirs, filepath.Join(g.config.OutputDir, "tests"))
+		modelsDir(g.config),
 	}
 
 	for _, dir := range dirs {
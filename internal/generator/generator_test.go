@@ -0,0 +1,144 @@
+package generator
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chashtager/opensdkraft/internal/config"
+	"github.com/chashtager/opensdkraft/internal/utils"
+)
+
+var update = flag.Bool("update", false, "update the expected SDK in testdata/golden")
+
+// TestGenerateGolden compares the example SDK byte for byte with the
+// expected tree in testdata/golden. Run it with -update after changing the
+// templates, and review the changes to the tree.
+func TestGenerateGolden(t *testing.T) {
+	got := generateExample(t)
+	golden := filepath.Join("testdata", "golden")
+
+	if *update {
+		if err := os.RemoveAll(golden); err != nil {
+			t.Fatal(err)
+		}
+		for name, content := range got {
+			path := filepath.Join(golden, filepath.FromSlash(name))
+			if err := utils.CreateDirectory(filepath.Dir(path)); err != nil {
+				t.Fatal(err)
+			}
+			if err := utils.WriteFile(path, content); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return
+	}
+
+	want := readTree(t, golden)
+	for _, name := range sortedFileNames(want, got) {
+		a, inWant := want[name]
+		b, inGot := got[name]
+		switch {
+		case !inWant:
+			t.Errorf("%s generated but not expected", name)
+		case !inGot:
+			t.Errorf("%s expected but not generated", name)
+		case !bytes.Equal(a, b):
+			t.Errorf("%s differs from the expected SDK:\n%s", name,
+				utils.UnifiedDiff("want/"+name, "got/"+name, string(a), string(b)))
+		}
+	}
+}
+
+// TestGenerateDeterministic generates the example SDK twice and compares
+// the trees byte for byte
+func TestGenerateDeterministic(t *testing.T) {
+	first := generateExample(t)
+	second := generateExample(t)
+
+	for _, name := range sortedFileNames(first, second) {
+		a, inFirst := first[name]
+		b, inSecond := second[name]
+		switch {
+		case !inFirst:
+			t.Errorf("%s only generated the second time", name)
+		case !inSecond:
+			t.Errorf("%s only generated the first time", name)
+		case !bytes.Equal(a, b):
+			t.Errorf("%s differs between generations", name)
+		}
+	}
+}
+
+// generateExample generates openapi-example.yaml and returns the contents
+// of the files by path relative to the SDK
+func generateExample(t *testing.T) map[string][]byte {
+	t.Helper()
+	return readTree(t, generate(t, filepath.Join("..", "..", "openapi-example.yaml")))
+}
+
+// generate generates spec with the default config.yaml into a new
+// directory, and returns it
+func generate(t *testing.T, spec string) string {
+	t.Helper()
+
+	cfg, err := config.LoadConfig(filepath.Join("..", "..", "config.yaml"))
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	cfg.OutputDir = t.TempDir()
+
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatalf("failed to initialize generator: %v", err)
+	}
+	err = gen.Generate(spec)
+	gen.Close()
+	if err != nil {
+		t.Fatalf("failed to generate SDK: %v", err)
+	}
+	return cfg.OutputDir
+}
+
+// readTree returns the contents of the files of a generated SDK by path
+// relative to dir. The log is left out, since it has timestamps.
+func readTree(t *testing.T, dir string) map[string][]byte {
+	t.Helper()
+
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == "generation.log" {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		files[filepath.ToSlash(rel)] = content
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to read generated SDK: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no files generated")
+	}
+	return files
+}
+
+// sortedFileNames returns the names of the files of both trees
+func sortedFileNames(trees ...map[string][]byte) []string {
+	names := make(map[string]bool)
+	for _, tree := range trees {
+		for name := range tree {
+			names[name] = true
+		}
+	}
+	return utils.SortedKeys(names)
+}
@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/chashtager/opensdkraft/internal/config"
+)
+
+// The package layout of a generated SDK. Methods of the client must be
+// declared in its package, so the operations always live next to it. With
+// the packages layout the models get a package of their own, which the
+// client package imports.
+
+// modelsPackage returns the name of the package the models are generated
+// into
+func modelsPackage(cfg *config.Config) string {
+	if cfg.Generator.Layout == config.LayoutFlat {
+		return cfg.PackageName
+	}
+	return "models"
+}
+
+// modelsImportPath returns the import path of the models package
+func modelsImportPath(cfg *config.Config) string {
+	if cfg.Generator.Layout == config.LayoutFlat {
+		return cfg.Module
+	}
+	return path.Join(cfg.Module, "models")
+}
+
+// modelsDir returns the directory the models are generated into
+func modelsDir(cfg *config.Config) string {
+	if cfg.Generator.Layout == config.LayoutFlat {
+		return cfg.OutputDir
+	}
+	return filepath.Join(cfg.OutputDir, "models")
+}
+
+// modelFile returns the file the model called name is generated into. In
+// the flat layout model files share the directory of the client, so they
+// get a suffix keeping a model named e.g. Client from overwriting client.go.
+func modelFile(cfg *config.Config, name string) string {
+	fileName := strings.ToLower(name)
+	if cfg.Generator.Layout == config.LayoutFlat {
+		fileName += "_model"
+	}
+	return filepath.Join(modelsDir(cfg), fileName+".go")
+}
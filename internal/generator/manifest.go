@@ -0,0 +1,242 @@
+package generator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chashtager/opensdkraft/internal/errors"
+	"github.com/chashtager/opensdkraft/internal/logging"
+	"github.com/chashtager/opensdkraft/internal/utils"
+)
+
+// manifestFile lists the files of the last generation, in the output
+// directory
+const manifestFile = ".sdkraft-manifest.json"
+
+// Manifest records the files a generation produced
+type Manifest struct {
+	// SHA-256 hashes of the generated contents by path relative to the
+	// output directory, in slash form. Hand-written code in keep regions
+	// is left out.
+	Files map[string]string `json:"files"`
+}
+
+// outputWriter writes the generated files. Files whose content has not
+// changed are left untouched, and files the previous generation did not
+// produce are not overwritten unless forced. Hand-written code in the keep
+// regions of a file is carried over to its new version.
+type outputWriter struct {
+	root   string
+	force  bool
+	logger *logging.Logger
+
+	previous map[string]string // hashes listed in the manifest
+	current  map[string]string // hashes of the files produced by this run
+
+	written   int
+	unchanged int
+}
+
+func newOutputWriter(root string, force bool, logger *logging.Logger) *outputWriter {
+	return &outputWriter{
+		root:     root,
+		force:    force,
+		logger:   logger,
+		previous: make(map[string]string),
+		current:  make(map[string]string),
+	}
+}
+
+// load reads the manifest of the previous generation, if any
+func (w *outputWriter) load() error {
+	content, err := os.ReadFile(filepath.Join(w.root, manifestFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.FileSystemError(err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		if w.force {
+			w.logger.Warn("Ignoring invalid manifest %s: %v", manifestFile, err)
+			return nil
+		}
+		return errors.Wrap(errors.ErrCodeFileSystemError,
+			fmt.Sprintf("invalid manifest %s, use --force to regenerate", manifestFile), err)
+	}
+	if manifest.Files != nil {
+		w.previous = manifest.Files
+	}
+	return nil
+}
+
+// write writes a generated file unless it already has the content
+func (w *outputWriter) write(filename string, content []byte) error {
+	if w == nil {
+		return utils.WriteFile(filename, content)
+	}
+
+	rel := w.relative(filename)
+	if strings.HasSuffix(rel, customFileSuffix) {
+		return errors.New(errors.ErrCodeFileSystemError, fmt.Sprintf(
+			"refusing to generate %s, names ending in %s are reserved for hand-written files",
+			rel, customFileSuffix))
+	}
+
+	existing, err := os.ReadFile(filename)
+	switch {
+	case err == nil:
+		recorded, listed := w.previous[rel]
+		if _, produced := w.current[rel]; !listed && !produced && !w.force {
+			return errors.New(errors.ErrCodeFileSystemError, fmt.Sprintf(
+				"refusing to overwrite %s, which is not listed in %s; use --force to overwrite it",
+				rel, manifestFile))
+		}
+
+		if content, err = w.keepRegions(rel, content, existing); err != nil {
+			return err
+		}
+		if bytes.Equal(existing, content) {
+			w.current[rel] = contentHash(stripRegions(content))
+			w.unchanged++
+			return nil
+		}
+		if listed && contentHash(stripRegions(existing)) != recorded {
+			w.logger.Warn("Overwriting %s, which was modified outside of keep regions since it was generated", rel)
+		}
+	case !os.IsNotExist(err):
+		return errors.FileSystemError(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return errors.FileSystemError(err)
+	}
+	if err := utils.WriteFile(filename, content); err != nil {
+		return err
+	}
+	w.current[rel] = contentHash(stripRegions(content))
+	w.written++
+	return nil
+}
+
+// keepRegions returns the new content of a file with the hand-written code
+// of the keep regions of its existing version. Code that would be lost,
+// because its region is no longer generated or the regions cannot be read,
+// is only dropped if forced.
+func (w *outputWriter) keepRegions(rel string, content, existing []byte) ([]byte, error) {
+	merged, dropped, err := mergeRegions(content, existing)
+	switch {
+	case err != nil && w.force:
+		w.logger.Warn("Dropping the keep regions of %s: %v", rel, err)
+		return content, nil
+	case err != nil:
+		return nil, errors.Wrap(errors.ErrCodeFileSystemError,
+			fmt.Sprintf("cannot keep the hand-written code of %s, use --force to overwrite it", rel), err)
+	case len(dropped) > 0 && w.force:
+		w.logger.Warn("Dropping keep regions %s of %s, which are no longer generated",
+			regionNames(dropped), rel)
+	case len(dropped) > 0:
+		return nil, errors.New(errors.ErrCodeFileSystemError, fmt.Sprintf(
+			"keep regions %s of %s are no longer generated; move their code or use --force to drop it",
+			regionNames(dropped), rel))
+	}
+	return merged, nil
+}
+
+// finish writes the manifest. If the generation is complete, files of the
+// previous generation that were not produced again are removed, unless they
+// were modified since. Otherwise they are kept listed.
+func (w *outputWriter) finish(complete bool) error {
+	files := make(map[string]string, len(w.current))
+	for rel, sum := range w.current {
+		files[rel] = sum
+	}
+
+	removed := 0
+	for _, rel := range utils.SortedKeys(w.previous) {
+		if _, ok := files[rel]; ok {
+			continue
+		}
+		filename := filepath.Join(w.root, filepath.FromSlash(rel))
+		if !complete {
+			if _, err := os.Stat(filename); err == nil {
+				files[rel] = w.previous[rel]
+			}
+			continue
+		}
+
+		stale, err := w.isUnmodified(filename, w.previous[rel])
+		if err != nil {
+			return err
+		}
+		if !stale {
+			w.logger.Warn("Keeping %s, which is no longer generated but was modified", rel)
+			continue
+		}
+		if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+			return errors.FileSystemError(err)
+		}
+		w.removeEmptyDirs(filepath.Dir(filename))
+		removed++
+	}
+
+	content, err := json.MarshalIndent(Manifest{Files: files}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := utils.WriteFile(filepath.Join(w.root, manifestFile), append(content, '\n')); err != nil {
+		return errors.FileSystemError(err)
+	}
+
+	w.logger.Info("Wrote %d files, %d unchanged, %d removed", w.written, w.unchanged, removed)
+	return nil
+}
+
+// isUnmodified reports whether a file still has the content it was
+// generated with, and no hand-written code in keep regions. Files that are
+// gone count as unmodified.
+func (w *outputWriter) isUnmodified(filename, sum string) (bool, error) {
+	content, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, errors.FileSystemError(err)
+	}
+	generated := stripRegions(content)
+	return bytes.Equal(generated, content) && contentHash(generated) == sum, nil
+}
+
+// removeEmptyDirs removes dir and its parents within the output directory
+// as long as they are empty
+func (w *outputWriter) removeEmptyDirs(dir string) {
+	root := filepath.Clean(w.root)
+	for dir = filepath.Clean(dir); dir != root && strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			return
+		}
+	}
+}
+
+// relative returns the path of a file relative to the output directory, in
+// slash form
+func (w *outputWriter) relative(filename string) string {
+	if rel, err := filepath.Rel(w.root, filename); err == nil {
+		filename = rel
+	}
+	return filepath.ToSlash(filename)
+}
+
+// contentHash returns the hex-encoded SHA-256 hash of content
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"path/filepath"
+
+	"github.com/chashtager/opensdkraft/internal/config"
+	"github.com/chashtager/opensdkraft/internal/errors"
+)
+
+// userAgent returns the User-Agent generated clients send, e.g. MySDK/1.2.0
+func userAgent(cfg *config.Config) string {
+	if cfg.SDKVersion == "" {
+		return cfg.SDKName
+	}
+	return cfg.SDKName + "/" + cfg.SDKVersion
+}
+
+func (g *OperationGenerator) generateMiddlewareFile() error {
+	data := struct {
+		PackageName string
+		UserAgent   string
+		Config      *config.Config
+	}{
+		PackageName: g.config.PackageName,
+		UserAgent:   userAgent(g.config),
+		Config:      g.config,
+	}
+
+	content, err := g.templates.Execute("middleware", data)
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeTemplateError,
+			"failed to execute middleware template", err)
+	}
+
+	filename := filepath.Join(g.config.OutputDir, "middleware.go")
+	if err := g.output.write(filename, content); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError,
+			"failed to write middleware file", err)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"errors"
 	"fmt"
 	"github.com/chashtager/opensdkraft/internal/config"
 	"github.com/chashtager/opensdkraft/internal/logging"
@@ -16,6 +17,8 @@ type ModelGenerator struct {
 	config     *config.Config
 	templates  *TemplateEngine
 	typeMapper *TypeMapper
+	sources    *sourceMap
+	output     *outputWriter
 	logger     *logging.Logger
 }
 
@@ -29,14 +32,29 @@ func NewModelGenerator(config *config.Config, templates *TemplateEngine, logger
 }
 
 type TypeMapper struct {
-	config     *config.Config
-	knownTypes map[string]string
+	config      *config.Config
+	knownTypes  map[string]string
+	namedTypes  map[string]*openapi3.Schema
+	inlineTypes []InlineType
+
+	// Package and import path qualifying model names, if the mapped types
+	// are used outside the models package
+	modelsPackage string
+	modelsImport  string
+}
+
+// InlineType is an anonymous object schema that has been hoisted into its own
+// named model so that it can be referenced from the enclosing type.
+type InlineType struct {
+	Name   string
+	Schema *openapi3.SchemaRef
 }
 
 func NewTypeMapper(config *config.Config) *TypeMapper {
 	tm := &TypeMapper{
 		config:     config,
 		knownTypes: make(map[string]string),
+		namedTypes: make(map[string]*openapi3.Schema),
 	}
 	tm.initializeKnownTypes()
 	return tm
@@ -72,38 +90,145 @@ func (tm *TypeMapper) initializeKnownTypes() {
 func (g *ModelGenerator) Generate(schemas openapi3.Schemas) error {
 	var validationErrors ValidationErrors
 
-	for name, schema := range schemas {
-		if err := g.generateModel(name, schema); err != nil {
+	// Register component names up front so that references between models
+	// resolve regardless of the order in which they are generated
+	names := utils.SortedKeys(schemas)
+	for _, name := range names {
+		g.typeMapper.RegisterNamedType(name, schemas[name])
+	}
+
+	for _, name := range names {
+		pointer := jsonPointer("components", "schemas", name)
+		if err := g.generateModel(name, pointer, schemas[name]); err != nil {
 			if valErr, ok := err.(*ValidationError); ok {
 				validationErrors.Errors = append(validationErrors.Errors, *valErr)
 			} else {
-				validationErrors.Add("Model", name, err.Error())
+				validationErrors.AddSource("Model", name, pointer, err.Error())
 			}
+			continue
+		}
+		g.sources.add(modelFile(g.config, name), pointer)
+	}
+
+	// Generate the inline objects hoisted while processing the models above
+	g.generateInlineTypes(g.typeMapper.TakeInlineTypes(), &validationErrors)
+
+	// Ptr and Nullable used by optional and nullable fields
+	if err := g.generateHelpers("optional", "optional.go"); err != nil {
+		validationErrors.Add("Model", "optional", err.Error())
+	}
+
+	// Shared helpers used by the generated Validate methods
+	if g.config.Generator.IncludeValidation {
+		if err := g.generateHelpers("validation", "validate.go"); err != nil {
+			validationErrors.Add("Model", "validation", err.Error())
+		}
+	}
+
+	if len(validationErrors.Errors) > 0 {
+		return &validationErrors
+	}
+
+	return nil
+}
+
+// GenerateInline generates models for inline schemas hoisted outside of the
+// components, such as the bodies of responses
+func (g *ModelGenerator) GenerateInline(types []InlineType) error {
+	var validationErrors ValidationErrors
+
+	for _, t := range types {
+		if existing, ok := g.typeMapper.namedTypes[t.Name]; ok && existing != t.Schema.Value {
+			validationErrors.AddSource("Model", t.Name, g.sources.schemaPointer(t.Schema),
+				fmt.Sprintf("inline schema %s has the name of another model", t.Name))
+			continue
 		}
+		g.typeMapper.namedTypes[t.Name] = t.Schema.Value
+	}
+	if len(validationErrors.Errors) == 0 {
+		g.generateInlineTypes(types, &validationErrors)
 	}
 
 	if len(validationErrors.Errors) > 0 {
 		return &validationErrors
 	}
+	return nil
+}
+
+// generateInlineTypes generates the models of hoisted inline schemas. They
+// may hoist further types, so it keeps going until none are left.
+func (g *ModelGenerator) generateInlineTypes(inline []InlineType, validationErrors *ValidationErrors) {
+	for ; len(inline) > 0; inline = g.typeMapper.TakeInlineTypes() {
+		for _, t := range inline {
+			pointer := g.sources.schemaPointer(t.Schema)
+			if err := g.generateModel(t.Name, pointer, t.Schema); err != nil {
+				if valErr, ok := err.(*ValidationError); ok {
+					validationErrors.Errors = append(validationErrors.Errors, *valErr)
+				} else {
+					validationErrors.AddSource("Model", t.Name, pointer, err.Error())
+				}
+				continue
+			}
+			g.sources.add(modelFile(g.config, t.Name), pointer)
+		}
+	}
+}
+
+// generateHelpers renders a template of model-independent helpers into the
+// models package
+func (g *ModelGenerator) generateHelpers(templateName, fileName string) error {
+	data := struct {
+		PackageName string
+		Config      *config.Config
+	}{
+		PackageName: modelsPackage(g.config),
+		Config:      g.config,
+	}
+
+	content, err := g.templates.Execute(templateName, data)
+	if err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	filename := filepath.Join(modelsDir(g.config), fileName)
+	if err := g.output.write(filename, content); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
 
 	return nil
 }
 
-func (g *ModelGenerator) generateModel(name string, schema *openapi3.SchemaRef) error {
+// generateModel generates the model of the schema declared at pointer
+func (g *ModelGenerator) generateModel(name, pointer string, schema *openapi3.SchemaRef) error {
+	if schema != nil && schema.Value != nil && isUnion(schema.Value) {
+		return g.generateUnion(name, pointer, schema)
+	}
+	if schema != nil && schema.Value != nil && isEnum(schema.Value) {
+		return g.generateEnum(name, pointer, schema)
+	}
+
 	modelData, err := g.prepareModelData(name, schema)
 	if err != nil {
+		var valErr *ValidationError
+		if errors.As(err, &valErr) {
+			valErr.Source = Source{Pointer: pointer}
+			return valErr
+		}
 		return &ValidationError{
 			Category: "Model",
 			Path:     name,
+			Source:   Source{Pointer: pointer},
 			Errors:   []string{err.Error()},
 		}
 	}
+	modelData.Source = g.sources.source(pointer)
 
 	// Validate the model data
 	if errs := g.validateModelData(modelData); len(errs) > 0 {
 		return &ValidationError{
 			Category: "Model",
 			Path:     name,
+			Source:   modelData.Source,
 			Errors:   errs,
 		}
 	}
@@ -114,8 +239,8 @@ func (g *ModelGenerator) generateModel(name string, schema *openapi3.SchemaRef)
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	filename := filepath.Join(g.config.OutputDir, "models", strings.ToLower(name)+".go")
-	if err := utils.WriteFile(filename, content); err != nil {
+	filename := modelFile(g.config, name)
+	if err := g.output.write(filename, content); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
@@ -183,22 +308,29 @@ func isValidGoIdentifier(name string) bool {
 type ModelData struct {
 	Name        string
 	PackageName string
+	Embeds      []string
 	Properties  []PropertyData
+	Patterns    []PatternData
 	Imports     []string
 	Config      *config.Config
 	Description string
+	Source      Source
 }
 
 type PropertyData struct {
-	Name         string
-	Type         string
-	JSONName     string
-	Required     bool
-	Description  string
-	Validate     string
-	Validation   []string
-	ZeroValue    string
-	ExampleValue string
+	Name           string
+	Type           string
+	JSONName       string
+	Required       bool
+	Nullable       bool
+	Description    string
+	Validate       string
+	Validation     []ValidationRule
+	ValueExpr      string
+	ValidateNested string
+	ZeroValue      string
+	ExampleValue   string
+	Source         Source
 }
 
 func (g *ModelGenerator) prepareModelData(name string, schema *openapi3.SchemaRef) (*ModelData, error) {
@@ -208,64 +340,321 @@ func (g *ModelGenerator) prepareModelData(name string, schema *openapi3.SchemaRe
 
 	modelData := &ModelData{
 		Name:        g.typeMapper.ToGoName(name),
-		PackageName: g.config.PackageName,
+		PackageName: modelsPackage(g.config),
 		Config:      g.config,
 		Imports:     make([]string, 0),
 		Description: schema.Value.Description,
 	}
 
+	// Flatten allOf members into a single property set
+	composed := newComposedSchema()
+	g.composeSchema(schema, composed)
+	modelData.Embeds = composed.embeds
+
 	seenImports := make(map[string]bool)
+	seenProperties := make(map[string]string)
+	var conflicts []string
+
+	// Fields promoted from embedded models take part in conflict detection
+	for _, embed := range composed.embedded {
+		promoted := newComposedSchema()
+		g.composeSchema(embed.schema, promoted)
+		for _, propName := range promoted.order {
+			propData, _, err := g.preparePropertyData(embed.name, propName, promoted.properties[propName], nil)
+			if err != nil {
+				return nil, err
+			}
+			seenProperties[propName] = propData.Type
+		}
+	}
 
-	for propName, propSchema := range schema.Value.Properties {
-		propData, imports, err := g.preparePropertyData(propName, propSchema, schema.Value.Required)
+	for _, propName := range composed.order {
+		propData, imports, err := g.preparePropertyData(modelData.Name, propName, composed.properties[propName], composed.required)
 		if err != nil {
 			return nil, err
 		}
 
-		modelData.Properties = append(modelData.Properties, *propData)
-
 		for _, imp := range imports {
 			if !seenImports[imp] {
 				modelData.Imports = append(modelData.Imports, imp)
 				seenImports[imp] = true
 			}
 		}
+
+		if existing, ok := seenProperties[propName]; ok && existing != propData.Type {
+			conflicts = append(conflicts, fmt.Sprintf(
+				"property %s has conflicting types %s and %s", propName, existing, propData.Type))
+			continue
+		}
+		seenProperties[propName] = propData.Type
+		propData.Source = g.sources.source(composed.sources[propName])
+
+		if err := g.prepareValidation(modelData, propData, composed.properties[propName]); err != nil {
+			conflicts = append(conflicts, err.Error())
+			continue
+		}
+
+		modelData.Properties = append(modelData.Properties, *propData)
+	}
+
+	if len(conflicts) > 0 {
+		return nil, &ValidationError{
+			Category: "Model",
+			Path:     name,
+			Errors:   conflicts,
+		}
 	}
 
 	return modelData, nil
 }
 
-func (g *ModelGenerator) preparePropertyData(name string, schema *openapi3.SchemaRef, required []string) (*PropertyData, []string, error) {
+// composedSchema is the result of flattening a schema and its allOf members
+type composedSchema struct {
+	embeds     []string
+	embedded   []embeddedSchema
+	properties map[string]*openapi3.SchemaRef
+	sources    map[string]string // JSON pointers of the properties
+	order      []string
+	required   []string
+}
+
+func newComposedSchema() *composedSchema {
+	return &composedSchema{
+		properties: make(map[string]*openapi3.SchemaRef),
+		sources:    make(map[string]string),
+	}
+}
+
+type embeddedSchema struct {
+	name   string
+	schema *openapi3.SchemaRef
+}
+
+// composeSchema merges schema into out. allOf members that reference a named
+// object component are embedded, all other members are flattened into out
+// with their required lists unioned.
+func (g *ModelGenerator) composeSchema(schema *openapi3.SchemaRef, out *composedSchema) {
+	if schema == nil || schema.Value == nil {
+		return
+	}
+
+	for _, member := range schema.Value.AllOf {
+		if refName := schemaRefName(member.Ref); refName != "" && g.typeMapper.IsStructType(member) {
+			goName := g.typeMapper.ToGoName(refName)
+			out.embeds = append(out.embeds, goName)
+			out.embedded = append(out.embedded, embeddedSchema{name: goName, schema: member})
+			continue
+		}
+		g.composeSchema(member, out)
+	}
+
+	// kin-openapi does not keep the declaration order of properties, so
+	// fields are generated in alphabetical order
+	owner := g.sources.schemaPointer(schema)
+	for _, propName := range utils.SortedKeys(schema.Value.Properties) {
+		if _, ok := out.properties[propName]; !ok {
+			out.order = append(out.order, propName)
+		}
+		out.properties[propName] = schema.Value.Properties[propName]
+		if owner != "" {
+			out.sources[propName] = appendPointer(owner, "properties", propName)
+		}
+	}
+
+	for _, req := range schema.Value.Required {
+		if !utils.StringContains(out.required, req) {
+			out.required = append(out.required, req)
+		}
+	}
+}
+
+func (g *ModelGenerator) preparePropertyData(parent, name string, schema *openapi3.SchemaRef, required []string) (*PropertyData, []string, error) {
 	if schema == nil || schema.Value == nil {
 		return nil, nil, fmt.Errorf("invalid property schema for %s", name)
 	}
 
-	goType, imports := g.typeMapper.ToGoType(schema)
+	goType, imports := g.typeMapper.ToGoTypeNamed(schema, parent+g.typeMapper.ToGoName(name))
 	isRequired := utils.StringContains(required, name)
 	validate := g.generateValidationTag(schema, isRequired)
 
+	// Enum types compare against the zero value of their base type
+	zeroValue := g.getZeroValue(goType)
+	if baseType := g.typeMapper.EnumBaseType(schema); baseType != "" {
+		zeroValue = g.getZeroValue(baseType)
+	}
+	exampleValue := g.getExampleValue(schema)
+
+	// Nullable fields keep absent and null apart with the generated
+	// Nullable[T]; other optional scalars become pointers when configured
+	switch {
+	case schema.Value.Nullable && g.config.CodeStyle.NullableStyle == config.NullableStyleGeneric:
+		if exampleValue != "nil" {
+			exampleValue = fmt.Sprintf("NewNullable[%s](%s)", goType, exampleValue)
+		}
+		goType = "Nullable[" + goType + "]"
+		zeroValue = "nil"
+	case g.typeMapper.IsStructType(schema):
+		goType = "*" + goType
+	case isScalarType(goType) && (schema.Value.Nullable || (!isRequired && g.config.CodeStyle.UsePointers)):
+		if exampleValue != "nil" {
+			exampleValue = fmt.Sprintf("Ptr[%s](%s)", goType, exampleValue)
+		}
+		goType = "*" + goType
+		zeroValue = "nil"
+	}
+
 	return &PropertyData{
 		Name:         g.typeMapper.ToGoName(name),
 		Type:         goType,
 		JSONName:     name,
 		Required:     isRequired,
+		Nullable:     schema.Value.Nullable,
 		Description:  schema.Value.Description,
 		Validate:     validate,
-		ZeroValue:    g.getZeroValue(goType),
-		ExampleValue: g.getExampleValue(schema),
+		ZeroValue:    zeroValue,
+		ExampleValue: exampleValue,
 	}, imports, nil
 }
 
+// isScalarType reports whether goType is a value type whose zero value cannot
+// be told apart from an absent field, i.e. anything but slices, maps and
+// interfaces.
+func isScalarType(goType string) bool {
+	return !strings.HasPrefix(goType, "[]") &&
+		!strings.HasPrefix(goType, "map[") &&
+		!strings.HasPrefix(goType, "*") &&
+		goType != "interface{}"
+}
+
+// RegisterNamedType records a component schema so that references to it
+// resolve to its generated model name.
+func (tm *TypeMapper) RegisterNamedType(name string, schema *openapi3.SchemaRef) {
+	if schema == nil {
+		return
+	}
+	tm.namedTypes[tm.ToGoName(name)] = schema.Value
+}
+
+// TakeInlineTypes returns the inline object schemas hoisted since the last
+// call and clears the pending list.
+func (tm *TypeMapper) TakeInlineTypes() []InlineType {
+	inline := tm.inlineTypes
+	tm.inlineTypes = nil
+	return inline
+}
+
+// hoistInlineType registers an anonymous object schema under name and returns
+// the name it was registered as. A numeric suffix is added when the name is
+// already taken by a different schema.
+func (tm *TypeMapper) hoistInlineType(name string, schema *openapi3.SchemaRef) string {
+	candidate := name
+	for i := 2; ; i++ {
+		existing, ok := tm.namedTypes[candidate]
+		if !ok {
+			break
+		}
+		if existing == schema.Value {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+
+	tm.namedTypes[candidate] = schema.Value
+	tm.inlineTypes = append(tm.inlineTypes, InlineType{Name: candidate, Schema: schema})
+	return candidate
+}
+
+// QualifyModels makes the mapper refer to generated models as members of the
+// package pkg imported from importPath, for types used outside of it.
+func (tm *TypeMapper) QualifyModels(pkg, importPath string) {
+	tm.modelsPackage = pkg
+	tm.modelsImport = importPath
+}
+
+// modelType returns the Go type of the generated model called name and the
+// imports it needs.
+func (tm *TypeMapper) modelType(name string) (string, []string) {
+	if tm.modelsPackage == "" {
+		return name, nil
+	}
+	return tm.modelsPackage + "." + name, []string{tm.modelsImport}
+}
+
+// IsStructType reports whether the schema is generated as a Go struct, either
+// through a reference to an object component or as a hoisted inline object.
+func (tm *TypeMapper) IsStructType(schema *openapi3.SchemaRef) bool {
+	if schema == nil || schema.Value == nil {
+		return false
+	}
+	if isRefAlias(schema.Value) {
+		return tm.IsStructType(schema.Value.AllOf[0])
+	}
+	if len(schema.Value.AllOf) > 0 || isUnion(schema.Value) {
+		return true
+	}
+	return isObjectType(schema.Value) && len(schema.Value.Properties) > 0
+}
+
+// isRefAlias reports whether the schema only wraps a single allOf member, a
+// pattern commonly used to attach a description to a reference.
+func isRefAlias(schema *openapi3.Schema) bool {
+	return len(schema.AllOf) == 1 && len(schema.Properties) == 0
+}
+
+// isObjectType reports whether the schema is an object, treating schemas
+// that declare properties without a type as objects.
+func isObjectType(schema *openapi3.Schema) bool {
+	if len(schema.Type.Slice()) == 0 {
+		return len(schema.Properties) > 0
+	}
+	return schema.Type.Is("object")
+}
+
+// ToGoType maps a schema to a Go type. Anonymous inline objects cannot be
+// named here and fall back to a generic map; use ToGoTypeNamed to hoist them.
 func (tm *TypeMapper) ToGoType(schema *openapi3.SchemaRef) (string, []string) {
+	return tm.ToGoTypeNamed(schema, "")
+}
+
+// ToGoTypeNamed maps a schema to a Go type. References to component schemas
+// resolve to the generated model name, and anonymous inline objects are
+// hoisted into a model called name.
+func (tm *TypeMapper) ToGoTypeNamed(schema *openapi3.SchemaRef, name string) (string, []string) {
 	if schema == nil || schema.Value == nil {
 		return "interface{}", nil
 	}
 
+	if refName := schemaRefName(schema.Ref); refName != "" {
+		return tm.modelType(tm.ToGoName(refName))
+	}
+
+	// Composed schemas are always generated as their own model
+	if isRefAlias(schema.Value) {
+		return tm.ToGoTypeNamed(schema.Value.AllOf[0], name)
+	}
+	if len(schema.Value.AllOf) > 0 {
+		if name == "" {
+			return "map[string]interface{}", nil
+		}
+		return tm.modelType(tm.hoistInlineType(name, schema))
+	}
+	if isUnion(schema.Value) {
+		if name == "" {
+			return "interface{}", nil
+		}
+		return tm.modelType(tm.hoistInlineType(name, schema))
+	}
+	if isEnum(schema.Value) && name != "" {
+		return tm.modelType(tm.hoistInlineType(name, schema))
+	}
+
 	var imports []string
 	// Since Type is []string, we need to check if it's not empty and take the first type
 	schemaType := ""
-	if len(*schema.Value.Type) > 0 {
+	if len(schema.Value.Type.Slice()) > 0 {
 		schemaType = schema.Value.Type.Slice()[0]
+	} else if isObjectType(schema.Value) {
+		schemaType = "object"
 	}
 	format := schema.Value.Format
 
@@ -283,25 +672,23 @@ func (tm *TypeMapper) ToGoType(schema *openapi3.SchemaRef) (string, []string) {
 	switch schemaType {
 	case "array":
 		if schema.Value.Items != nil {
-			itemType, itemImports := tm.ToGoType(schema.Value.Items)
+			itemType, itemImports := tm.ToGoTypeNamed(schema.Value.Items, itemName(name))
 			imports = append(imports, itemImports...)
 			return "[]" + itemType, imports
 		}
 		return "[]interface{}", nil
 
 	case "object":
+		// Structured objects get their own model; inline ones are hoisted
+		if len(schema.Value.Properties) > 0 && name != "" {
+			return tm.modelType(tm.hoistInlineType(name, schema))
+		}
 		// Check for AdditionalProperties
-		if schema.Value.AdditionalProperties.Has != nil &&
-			schema.Value.AdditionalProperties.Schema != nil {
-			valueType, valueImports := tm.ToGoType(schema.Value.AdditionalProperties.Schema)
+		if schema.Value.AdditionalProperties.Schema != nil {
+			valueType, valueImports := tm.ToGoTypeNamed(schema.Value.AdditionalProperties.Schema, valueName(name))
 			imports = append(imports, valueImports...)
 			return "map[string]" + valueType, imports
 		}
-		// Handle specific object properties
-		if len(schema.Value.Properties) > 0 {
-			// This is a structured object, should be handled by model generation
-			return "struct", nil
-		}
 		return "map[string]interface{}", nil
 
 	case "string":
@@ -333,14 +720,41 @@ func (tm *TypeMapper) ToGoType(schema *openapi3.SchemaRef) (string, []string) {
 	}
 }
 
+// schemaRefName returns the component name a local schema reference points
+// to, or an empty string if ref does not reference a component schema.
+func schemaRefName(ref string) string {
+	const prefix = "#/components/schemas/"
+	idx := strings.Index(ref, prefix)
+	if idx < 0 {
+		return ""
+	}
+	return ref[idx+len(prefix):]
+}
+
+func itemName(name string) string {
+	if name == "" {
+		return ""
+	}
+	return name + "Item"
+}
+
+func valueName(name string) string {
+	if name == "" {
+		return ""
+	}
+	return name + "Value"
+}
+
 func (tm *TypeMapper) ToGoName(name string) string {
 	// Convert to PascalCase
 	words := strings.FieldsFunc(name, func(r rune) bool {
 		return !strings.ContainsRune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789", r)
 	})
 
+	// Only the first letter of each word is changed so that names which are
+	// already PascalCase (such as hoisted inline types) map to themselves
 	for i, word := range words {
-		words[i] = strings.Title(strings.ToLower(word))
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
 	}
 
 	return strings.Join(words, "")
@@ -354,17 +768,39 @@ func (g *ModelGenerator) getZeroValue(goType string) string {
 		return "0"
 	case "bool":
 		return "false"
+	case "time.Time":
+		return "(time.Time{})"
 	default:
 		return "nil"
 	}
 }
 
 func (g *ModelGenerator) getExampleValue(schema *openapi3.SchemaRef) string {
+	if isRefAlias(schema.Value) && schema.Value.Example == nil {
+		return g.getExampleValue(schema.Value.AllOf[0])
+	}
+
+	// Formats mapped to non-string Go types cannot take the raw example
+	switch schema.Value.Format {
+	case "date", "date-time":
+		return "time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)"
+	case "byte", "binary":
+		return `[]byte("example")`
+	}
+
 	if schema.Value.Example != nil {
 		return fmt.Sprintf("%#v", schema.Value.Example)
 	}
+	if len(schema.Value.Enum) > 0 && schema.Value.Enum[0] != nil {
+		return fmt.Sprintf("%#v", schema.Value.Enum[0])
+	}
 
-	switch schema.Value.Type.Slice()[0] {
+	schemaType := ""
+	if len(schema.Value.Type.Slice()) > 0 {
+		schemaType = schema.Value.Type.Slice()[0]
+	}
+
+	switch schemaType {
 	case "string":
 		return `"example"`
 	case "integer":
@@ -0,0 +1,44 @@
+package generator
+
+import (
+	"path/filepath"
+
+	"github.com/chashtager/opensdkraft/internal/config"
+	"github.com/chashtager/opensdkraft/internal/errors"
+)
+
+// generateModuleFiles writes the go.mod of the SDK, and the go.sum pinning
+// the dependencies of the generated code and tests
+func (g *Generator) generateModuleFiles() error {
+	data := struct {
+		Config *config.Config
+	}{
+		Config: g.config,
+	}
+
+	for _, file := range []struct {
+		template string
+		name     string
+	}{
+		{"gomod", "go.mod"},
+		{"gosum", "go.sum"},
+	} {
+		content, err := g.templateEngine.Execute(file.template, data)
+		if err != nil {
+			return errors.Wrap(errors.ErrCodeTemplateError,
+				"failed to execute "+file.template+" template", err)
+		}
+
+		// An SDK without dependencies has nothing to pin
+		if len(content) == 0 {
+			continue
+		}
+
+		if err := g.output.write(filepath.Join(g.config.OutputDir, file.name), content); err != nil {
+			return errors.Wrap(errors.ErrCodeFileSystemError,
+				"failed to write "+file.name, err)
+		}
+	}
+
+	return nil
+}
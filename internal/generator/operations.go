@@ -6,18 +6,31 @@ import (
 	"github.com/chashtager/opensdkraft/internal/errors"
 	"github.com/chashtager/opensdkraft/internal/logging"
 	"github.com/chashtager/opensdkraft/internal/utils"
+	"net/http"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
 type OperationGenerator struct {
-	config     *config.Config
-	templates  *TemplateEngine
-	operations []*Operation
-	typeMapper *TypeMapper
-	logger     *logging.Logger
+	config          *config.Config
+	templates       *TemplateEngine
+	operations      []*Operation
+	typeMapper      *TypeMapper
+	securitySchemes []SecurityScheme
+	globalSecurity  []SecurityRequirement
+	rateLimits      RateLimits
+	sources         *sourceMap
+	output          *outputWriter
+	logger          *logging.Logger
+
+	// Imports needed by the types of the operation being parsed, and by the
+	// example values of its test
+	imports     map[string]bool
+	testImports map[string]bool
 }
 
 type Operation struct {
@@ -29,50 +42,167 @@ type Operation struct {
 	ResponseType   string
 	Parameters     []Parameter
 	RequestBody    *RequestBody
-	Responses      map[string]Response
-	Authentication bool
+	Responses      []Response
+	Security       []SecurityRequirement
+	SecurityValue  string // Security as a Go literal
 	HasQueryParams bool
 	HasPathParams  bool
 	HasContext     bool
-	ZeroValue      string
+	Idempotent     bool // safe to retry
+	Tags           []string
+	RateLimit      *RateLimit // bucket of the operation's own x-rate-limit
+	Pagination     *Pagination
+	Stream         *Response // successful response read as a stream of events
+	RawBody        bool      // a successful response body is returned unread
+	Accept         string    // Accept header, if not JSON
+	Imports        []string  // packages of the types used, e.g. time or the models
+	TestImports    []string  // packages of the example values used by the test
+	Source         Source
 	ExampleValue   string
 }
 
 type Parameter struct {
-	Name         string
-	GoName       string
-	Type         string
-	Location     string // path, query, header
-	Required     bool
-	Description  string
-	JSONName     string
-	Validate     string
-	Validation   []string
-	ZeroValue    string
-	ExampleValue string
+	Name          string
+	GoName        string
+	Type          string
+	Location      string // path, query, header or cookie
+	Style         string // serialization style, e.g. form or matrix
+	Explode       bool
+	AllowReserved bool // reserved characters are not percent-encoded
+	Required      bool
+	Description   string
+	JSONName      string
+	Validate      string
+	Validation    []string
+	ExampleValue  string
+	Source        Source
+}
+
+// IsPointer reports whether the parameter is an optional scalar held by
+// address
+func (p Parameter) IsPointer() bool {
+	return strings.HasPrefix(p.Type, "*")
+}
+
+// ValueType is the type of the parameter's value, without the pointer of
+// optional scalars
+func (p Parameter) ValueType() string {
+	return strings.TrimPrefix(p.Type, "*")
+}
+
+// Nillable reports whether the parameter can be nil, the only way an unset
+// required parameter differs from a valid zero value such as 0 or false
+func (p Parameter) Nillable() bool {
+	return !isScalarType(p.Type)
 }
 
 type RequestBody struct {
 	Type         string
 	Required     bool
 	MediaType    string
+	Encoding     string // json, form, multipart, binary or text
+	Fields       []FormField
 	Description  string
 	ExampleValue string
+	Source       Source
+}
+
+// FormField is a property of a form-urlencoded or multipart request body
+type FormField struct {
+	Name     string
+	JSONName string
+	Type     string
+	Required bool
+	IsFile   bool
+	Source   Source
+}
+
+// IsPointer reports whether the field is only sent when set
+func (f FormField) IsPointer() bool {
+	return strings.HasPrefix(f.Type, "*")
+}
+
+// IsSlice reports whether each item of the field is sent as its own value
+func (f FormField) IsSlice() bool {
+	return strings.HasPrefix(f.Type, "[]")
 }
 
+// Response is a documented response of an operation
 type Response struct {
-	StatusCode  string
-	Type        string
+	StatusCode  string // e.g. "200", "4XX" or "default"
+	Condition   string // Go condition matching the status, empty for default
+	IsDefault   bool
+	IsError     bool
+	Field       string // response struct field holding a decoded JSON body
+	Type        string // type of Field
+	ValueType   string // type the body is decoded into
+	Stream      string // sse or ndjson if the body is streamed
+	Raw         bool   // the body is returned unread, e.g. a file download
 	MediaType   string
 	Description string
+	Source      Source
+}
+
+// IsPointer reports whether the decoded body is stored by address
+func (r Response) IsPointer() bool {
+	return strings.HasPrefix(r.Type, "*")
+}
+
+// parameterStyles lists the serialization styles allowed in each parameter
+// location
+var parameterStyles = map[string]map[string]bool{
+	openapi3.ParameterInPath: {
+		openapi3.SerializationSimple: true,
+		openapi3.SerializationLabel:  true,
+		openapi3.SerializationMatrix: true,
+	},
+	openapi3.ParameterInQuery: {
+		openapi3.SerializationForm:           true,
+		openapi3.SerializationSpaceDelimited: true,
+		openapi3.SerializationPipeDelimited:  true,
+		openapi3.SerializationDeepObject:     true,
+	},
+	openapi3.ParameterInHeader: {
+		openapi3.SerializationSimple: true,
+	},
+	openapi3.ParameterInCookie: {
+		openapi3.SerializationForm: true,
+	},
+}
+
+// httpMethods lists the operations of a path item in the order they are
+// generated
+var httpMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodHead,
+	http.MethodOptions,
+}
+
+// mediaTypes returns the media types of content with JSON first and the rest
+// in alphabetical order
+func mediaTypes(content openapi3.Content) []string {
+	types := utils.SortedKeys(content)
+	sort.SliceStable(types, func(i, j int) bool {
+		return types[i] == "application/json" && types[j] != "application/json"
+	})
+	return types
 }
 
 func NewOperationGenerator(config *config.Config, templates *TemplateEngine, logger *logging.Logger) *OperationGenerator {
+	typeMapper := NewTypeMapper(config)
+	if pkg := modelsPackage(config); pkg != config.PackageName {
+		typeMapper.QualifyModels(pkg, modelsImportPath(config))
+	}
+
 	return &OperationGenerator{
 		config:     config,
 		templates:  templates,
 		operations: make([]*Operation, 0),
-		typeMapper: NewTypeMapper(config),
+		typeMapper: typeMapper,
 		logger:     logger,
 	}
 }
@@ -83,26 +213,26 @@ func (g *OperationGenerator) Generate(paths *openapi3.Paths) error {
 		return errors.InvalidInput("paths cannot be nil")
 	}
 
-	// Create operations directory
-	operationsDir := filepath.Join(g.config.OutputDir, "operations")
-	g.logger.Debug("Creating operations directory: %s", operationsDir)
-	if err := utils.CreateDirectory(operationsDir); err != nil {
-		g.logger.Error("Failed to create operations directory: %v", err)
-		return errors.FileSystemError(err)
-	}
+	// Operations are methods of the client, so they are generated into its
+	// package
+	operationsDir := g.config.OutputDir
 
-	// Generate operations
+	// Generate operations, reporting every operation that fails
+	var validationErrors ValidationErrors
 	pathMap := paths.Map()
 	progress := g.logger.NewProgress(len(pathMap), "Generating operations")
-	for path, pathItem := range pathMap {
+	for _, path := range utils.SortedKeys(pathMap) {
 		g.logger.Debug("Processing path: %s", path)
-		if err := g.generatePathOperations(path, pathItem, operationsDir); err != nil {
+		if err := g.generatePathOperations(path, pathMap[path], operationsDir, &validationErrors); err != nil {
 			g.logger.Error("Failed to generate operations for path %s: %v", path, err)
 			return errors.Wrap(errors.ErrCodeGenerationFailed,
 				fmt.Sprintf("failed to generate operations for path %s", path), err)
 		}
 		progress.Increment()
 	}
+	if len(validationErrors.Errors) > 0 {
+		return &validationErrors
+	}
 
 	// Generate client file with all operations
 	g.logger.Info("Generating client file")
@@ -112,29 +242,75 @@ func (g *OperationGenerator) Generate(paths *openapi3.Paths) error {
 			"failed to generate client file", err)
 	}
 
+	if len(g.securitySchemes) > 0 {
+		g.logger.Info("Generating auth file")
+		if err := g.generateAuthFile(); err != nil {
+			g.logger.Error("Failed to generate auth file: %v", err)
+			return err
+		}
+	}
+
+	if hasParameters(g.operations) {
+		g.logger.Info("Generating params file")
+		if err := g.generateParamsFile(); err != nil {
+			g.logger.Error("Failed to generate params file: %v", err)
+			return err
+		}
+	}
+
+	if len(paginationKinds(g.operations)) > 0 {
+		g.logger.Info("Generating pagination file")
+		if err := g.generatePaginationFile(); err != nil {
+			g.logger.Error("Failed to generate pagination file: %v", err)
+			return err
+		}
+	}
+
+	if hasStreams(g.operations) {
+		g.logger.Info("Generating stream file")
+		if err := g.generateStreamFile(); err != nil {
+			g.logger.Error("Failed to generate stream file: %v", err)
+			return err
+		}
+	}
+
+	if g.config.Generator.ClientOptions.GenerateMiddleware {
+		g.logger.Info("Generating middleware file")
+		if err := g.generateMiddlewareFile(); err != nil {
+			g.logger.Error("Failed to generate middleware file: %v", err)
+			return err
+		}
+	}
+
+	if g.config.Generator.ClientOptions.IncludeRateLimiting {
+		g.logger.Info("Generating rate limit file")
+		if err := g.generateRateLimitFile(); err != nil {
+			g.logger.Error("Failed to generate rate limit file: %v", err)
+			return err
+		}
+	}
+
 	g.logger.Info("Successfully generated %d operations", len(g.operations))
 	return nil
 }
 
-func (g *OperationGenerator) generatePathOperations(path string, pathItem *openapi3.PathItem, outputDir string) error {
-	methods := map[string]*openapi3.Operation{
-		"GET":     pathItem.Get,
-		"POST":    pathItem.Post,
-		"PUT":     pathItem.Put,
-		"DELETE":  pathItem.Delete,
-		"PATCH":   pathItem.Patch,
-		"HEAD":    pathItem.Head,
-		"OPTIONS": pathItem.Options,
-	}
-
-	for method, op := range methods {
+// generatePathOperations generates the operations of a path. Operations the
+// spec does not describe well enough are added to errs.
+func (g *OperationGenerator) generatePathOperations(path string, pathItem *openapi3.PathItem, outputDir string, errs *ValidationErrors) error {
+	for _, method := range httpMethods {
+		op := pathItem.GetOperation(method)
 		if op == nil {
 			continue
 		}
 
-		operation, err := g.parseOperation(method, path, op)
+		operation, err := g.parseOperation(method, path, pathItem.Parameters, op)
 		if err != nil {
-			return err
+			valErr, ok := err.(*ValidationError)
+			if !ok {
+				return err
+			}
+			errs.Errors = append(errs.Errors, *valErr)
+			continue
 		}
 		g.operations = append(g.operations, operation)
 
@@ -147,27 +323,66 @@ func (g *OperationGenerator) generatePathOperations(path string, pathItem *opena
 	return nil
 }
 
-func (g *OperationGenerator) parseOperation(method, path string, op *openapi3.Operation) (*Operation, error) {
+// isIdempotent reports whether an operation can be retried safely. Methods
+// that are idempotent by definition can be, others only if the operation sets
+// the x-idempotent extension, which can also opt idempotent methods out.
+func isIdempotent(method string, op *openapi3.Operation) bool {
+	if idempotent, ok := op.Extensions["x-idempotent"].(bool); ok {
+		return idempotent
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func (g *OperationGenerator) parseOperation(method, path string, pathParams openapi3.Parameters, op *openapi3.Operation) (*Operation, error) {
+	g.imports = make(map[string]bool)
+	g.testImports = make(map[string]bool)
+	pointer := jsonPointer("paths", path, strings.ToLower(method))
+	fail := func(pointer string, err error) (*Operation, error) {
+		return nil, &ValidationError{
+			Category: "Operation",
+			Path:     method + " " + path,
+			Source:   Source{Pointer: pointer},
+			Errors:   []string{err.Error()},
+		}
+	}
+
 	operation := &Operation{
-		Name:           g.generateOperationName(method, path, op),
-		Method:         method,
-		Path:           path,
-		Description:    op.Description,
-		Parameters:     make([]Parameter, 0),
-		Responses:      make(map[string]Response),
-		Authentication: op.Security != nil,
-		HasContext:     g.config.Generator.ClientOptions.UseContext,
+		Name:         g.generateOperationName(method, path, op),
+		Method:       method,
+		Path:         path,
+		Description:  op.Description,
+		Parameters:   make([]Parameter, 0),
+		ResponseType: g.generateOperationName(method, path, op) + "Response",
+		Security:     g.operationSecurity(op),
+		HasContext:   g.config.Generator.ClientOptions.UseContext,
+		Idempotent:   isIdempotent(method, op),
+		Tags:         op.Tags,
+		Source:       g.sources.source(pointer),
+	}
+
+	rateLimit, err := parseRateLimit(op.Extensions)
+	if err != nil {
+		return fail(appendPointer(pointer, rateLimitExtension), err)
+	}
+	operation.RateLimit = rateLimit
+
+	if len(operation.Security) > 0 {
+		operation.SecurityValue = securityLiteral(operation.Security)
 	}
 
 	// Parse parameters
-	for _, paramRef := range op.Parameters {
-		if paramRef == nil || paramRef.Value == nil {
-			continue
-		}
+	for _, declared := range operationParameters(path, method, pathParams, op.Parameters) {
+		paramRef, paramPointer := declared.ref, declared.pointer
 		parameter, err := g.parseParameter(paramRef)
 		if err != nil {
-			return nil, err
+			return fail(paramPointer, err)
 		}
+		parameter.Source = g.sources.source(paramPointer)
 		operation.Parameters = append(operation.Parameters, *parameter)
 		// Set parameter type flags
 		switch paramRef.Value.In {
@@ -180,48 +395,140 @@ func (g *OperationGenerator) parseOperation(method, path string, op *openapi3.Op
 
 	// Parse request body
 	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		bodyPointer := appendPointer(pointer, "requestBody")
 		requestBody, err := g.parseRequestBody(op.RequestBody)
 		if err != nil {
-			return nil, err
+			return fail(bodyPointer, err)
 		}
+		requestBody.Source = g.sources.source(bodyPointer)
 		operation.RequestBody = requestBody
 	}
 
 	// Parse responses
-	for status, responseRef := range op.Responses.Map() {
+	responses := op.Responses.Map()
+	for _, status := range utils.SortedKeys(responses) {
+		responseRef := responses[status]
 		if responseRef == nil || responseRef.Value == nil {
 			continue
 		}
-		resp, err := g.parseResponse(status, responseRef)
+		respPointer := appendPointer(pointer, "responses", status)
+		resp, err := g.parseResponse(operation.Name, status, responseRef)
 		if err != nil {
-			return nil, err
+			return fail(respPointer, err)
+		}
+		resp.Source = g.sources.source(respPointer)
+		operation.Responses = append(operation.Responses, *resp)
+	}
+	sortResponses(operation.Responses)
+	for i := range operation.Responses {
+		if r := &operation.Responses[i]; r.Stream != "" && !r.IsError {
+			operation.Stream = r
+			break
 		}
-		operation.Responses[status] = *resp
+	}
+	if operation.Stream == nil {
+		operation.RawBody, operation.Accept = rawResponses(op, operation.Responses)
 	}
 
+	pagination, err := g.parsePagination(op, operation)
+	if err != nil {
+		return fail(appendPointer(pointer, paginationExtension), err)
+	}
+	operation.Pagination = pagination
+	operation.Imports = utils.SortedKeys(g.imports)
+	operation.TestImports = utils.SortedKeys(g.testImports)
+
 	return operation, nil
 }
 
+// declaredParameter is a parameter with the JSON pointer it is declared at
+type declaredParameter struct {
+	ref     *openapi3.ParameterRef
+	pointer string
+}
+
+// operationParameters returns the parameters of the path that the operation
+// does not redeclare, by name and location, followed by the operation's own
+func operationParameters(path, method string, pathParams, opParams openapi3.Parameters) []declaredParameter {
+	var params []declaredParameter
+	for i, paramRef := range pathParams {
+		if paramRef == nil || paramRef.Value == nil {
+			continue
+		}
+		if opParams.GetByInAndName(paramRef.Value.In, paramRef.Value.Name) != nil {
+			continue
+		}
+		params = append(params, declaredParameter{
+			ref:     paramRef,
+			pointer: jsonPointer("paths", path, "parameters", strconv.Itoa(i)),
+		})
+	}
+	for i, paramRef := range opParams {
+		if paramRef == nil || paramRef.Value == nil {
+			continue
+		}
+		params = append(params, declaredParameter{
+			ref:     paramRef,
+			pointer: jsonPointer("paths", path, strings.ToLower(method), "parameters", strconv.Itoa(i)),
+		})
+	}
+	return params
+}
+
+// goType maps a schema to a Go type for the operation being parsed and
+// records the imports the type needs
+func (g *OperationGenerator) goType(schema *openapi3.SchemaRef, name string) string {
+	goType, imports := g.typeMapper.ToGoTypeNamed(schema, name)
+	for _, imp := range imports {
+		g.imports[imp] = true
+	}
+	return goType
+}
+
 func (g *OperationGenerator) parseParameter(paramRef *openapi3.ParameterRef) (*Parameter, error) {
 	if paramRef.Value == nil {
 		return nil, errors.InvalidInput("parameter value is nil")
 	}
 
 	param := paramRef.Value
-	goType, _ := g.typeMapper.ToGoType(param.Schema)
+	method, err := param.SerializationMethod()
+	if err != nil {
+		return nil, errors.InvalidInput(fmt.Sprintf("parameter %s: %v", param.Name, err))
+	}
+	if !parameterStyles[param.In][method.Style] {
+		return nil, errors.InvalidInput(fmt.Sprintf("parameter %s: style %s is not allowed in %s",
+			param.Name, method.Style, param.In))
+	}
+
+	goType := g.goType(param.Schema, "")
 	validate := g.generateParamValidation(param)
 
+	// Tests only set the required parameters. An empty struct leaves a path
+	// parameter empty, which cannot be sent.
+	example := ""
+	if param.Required && (param.In != openapi3.ParameterInPath || !g.typeMapper.IsStructType(param.Schema)) {
+		example = g.exampleValue(param.Schema, goType)
+	}
+
+	// Optional scalars are pointers so that they can be left out
+	fieldType := goType
+	if !param.Required && isScalarType(goType) {
+		fieldType = "*" + goType
+	}
+
 	return &Parameter{
-		Name:         param.Name,
-		GoName:       g.typeMapper.ToGoName(param.Name),
-		Type:         goType,
-		Location:     param.In,
-		Required:     param.Required,
-		Description:  param.Description,
-		JSONName:     param.Name,
-		Validate:     validate,
-		ZeroValue:    g.getZeroValue(goType),
-		ExampleValue: g.getExampleValue(param.Schema),
+		Name:          g.typeMapper.ToGoName(param.Name),
+		GoName:        g.typeMapper.ToGoName(param.Name),
+		Type:          fieldType,
+		Location:      param.In,
+		Style:         method.Style,
+		Explode:       method.Explode,
+		AllowReserved: param.AllowReserved,
+		Required:      param.Required,
+		Description:   param.Description,
+		JSONName:      param.Name,
+		Validate:      validate,
+		ExampleValue:  example,
 	}, nil
 }
 
@@ -231,168 +538,313 @@ func (g *OperationGenerator) parseRequestBody(bodyRef *openapi3.RequestBodyRef)
 	}
 
 	body := bodyRef.Value
-	// Get the first content type and its schema
-	var mediaType string
-	var schema *openapi3.SchemaRef
-	for mt, content := range body.Content {
-		mediaType = mt
-		schema = content.Schema
-		break
+	mediaType := preferredRequestMediaType(body.Content)
+	if mediaType == "" {
+		return nil, errors.InvalidInput("request body has no content")
 	}
 
-	if schema == nil {
-		return nil, errors.InvalidInput("request body schema is nil")
+	requestBody := &RequestBody{
+		Required:    body.Required,
+		MediaType:   mediaType,
+		Encoding:    bodyEncoding(mediaType),
+		Description: body.Description,
 	}
 
-	goType, _ := g.typeMapper.ToGoType(schema)
-	exampleValue := g.generateExampleValue(schema)
-
-	return &RequestBody{
-		Type:         goType,
-		Required:     body.Required,
-		MediaType:    mediaType,
-		Description:  body.Description,
-		ExampleValue: exampleValue,
-	}, nil
-}
-
-func (g *OperationGenerator) generateExampleValue(schema *openapi3.SchemaRef) string {
-	if schema == nil || schema.Value == nil {
-		return "nil"
+	// Raw bytes need no schema, and are sent as such even when labeled JSON
+	schema := body.Content[mediaType].Schema
+	if isBinarySchema(schema) {
+		requestBody.Encoding = EncodingBinary
+	}
+	if (schema == nil || schema.Value == nil) && requestBody.Encoding != EncodingBinary && requestBody.Encoding != EncodingText {
+		return nil, errors.InvalidInput("request body schema is nil")
 	}
 
-	// If schema has an example, use it
-	if schema.Value.Example != nil {
-		return fmt.Sprintf("%#v", schema.Value.Example)
+	switch requestBody.Encoding {
+	case EncodingForm, EncodingMultipart:
+		if !schema.Value.Type.Is("object") && len(schema.Value.Properties) == 0 {
+			return nil, errors.InvalidInput(fmt.Sprintf("%s request body must be an object", mediaType))
+		}
+		requestBody.Fields = g.parseFormFields(schema, requestBody.Encoding == EncodingMultipart)
+	case EncodingBinary:
+		requestBody.Type = "io.Reader"
+		requestBody.ExampleValue = `strings.NewReader("example")`
+		g.testImports["strings"] = true
+	case EncodingText:
+		requestBody.Type = "string"
+		requestBody.ExampleValue = `"example"`
+	default:
+		requestBody.Type = g.goType(schema, "")
+		requestBody.ExampleValue = g.exampleValue(schema, requestBody.Type)
 	}
 
-	// Generate example based on schema type
-	schemaType := ""
-	if len(schema.Value.Type.Slice()) > 0 {
-		schemaType = schema.Value.Type.Slice()[0]
+	return requestBody, nil
+}
+
+// parseFormFields lists the properties of a form body in alphabetical order.
+// Binary properties of multipart bodies become file parts read from an
+// io.Reader.
+func (g *OperationGenerator) parseFormFields(schemaRef *openapi3.SchemaRef, multipart bool) []FormField {
+	// Properties of a referenced model use the enum and inline types
+	// generated for that model
+	parent := ""
+	if refName := schemaRefName(schemaRef.Ref); refName != "" {
+		parent = g.typeMapper.ToGoName(refName)
 	}
 
-	switch schemaType {
-	case "array":
-		if schema.Value.Items != nil {
-			itemExample := g.generateExampleValue(schema.Value.Items)
-			itemType, _ := g.typeMapper.ToGoType(schema.Value.Items)
-			return fmt.Sprintf("[]%s{%s}", itemType, itemExample)
+	schema := schemaRef.Value
+	owner := g.sources.schemaPointer(schemaRef)
+	var fields []FormField
+	for _, name := range utils.SortedKeys(schema.Properties) {
+		prop := schema.Properties[name]
+		required := utils.StringContains(schema.Required, name)
+		field := FormField{
+			Name:     g.typeMapper.ToGoName(name),
+			JSONName: name,
+			Required: required,
 		}
-		return "[]interface{}{}"
-
-	case "object":
-		if ref := schema.Ref; ref != "" {
-			// Reference to another schema
-			parts := strings.Split(ref, "/")
-			typeName := parts[len(parts)-1]
-			return fmt.Sprintf("&%s{}", g.typeMapper.ToGoName(typeName))
+		if owner != "" {
+			field.Source = g.sources.source(appendPointer(owner, "properties", name))
 		}
-		return "map[string]interface{}{}"
 
-	case "string":
-		if schema.Value.Enum != nil && len(schema.Value.Enum) > 0 {
-			return fmt.Sprintf("%#v", schema.Value.Enum[0])
+		if prop == nil || prop.Value == nil {
+			field.Type = "interface{}"
+			fields = append(fields, field)
+			continue
 		}
-		return `"example"`
 
-	case "integer":
-		if schema.Value.Format == "int64" {
-			return "int64(123)"
+		switch {
+		case multipart && prop.Value.Type.Is("string") && prop.Value.Format == "binary":
+			field.Type = "io.Reader"
+			field.IsFile = true
+		case multipart && prop.Value.Type.Is("array") && prop.Value.Items != nil &&
+			prop.Value.Items.Value != nil && prop.Value.Items.Value.Format == "binary":
+			field.Type = "[]io.Reader"
+			field.IsFile = true
+		default:
+			typeName := ""
+			if parent != "" {
+				typeName = parent + field.Name
+			}
+			field.Type = g.goType(prop, typeName)
+			if (g.typeMapper.IsStructType(prop) && !strings.HasPrefix(field.Type, "map[")) ||
+				(!required && g.config.CodeStyle.UsePointers && isScalarType(field.Type)) {
+				field.Type = "*" + field.Type
+			}
 		}
-		return "42"
 
-	case "number":
-		if schema.Value.Format == "float" {
-			return "float32(1.23)"
-		}
-		return "1.23"
+		fields = append(fields, field)
+	}
+	return fields
+}
 
-	case "boolean":
-		return "true"
+// Request body encodings supported by the generated client
+const (
+	EncodingJSON      = "json"
+	EncodingForm      = "form"
+	EncodingMultipart = "multipart"
+	EncodingBinary    = "binary"
+	EncodingText      = "text"
+)
 
+// bodyEncoding returns how a request body of the given media type is encoded.
+// Media types without a dedicated encoder are sent as raw bytes.
+func bodyEncoding(mediaType string) string {
+	switch {
+	case isJSONMediaType(mediaType):
+		return EncodingJSON
+	case mediaType == "application/x-www-form-urlencoded":
+		return EncodingForm
+	case mediaType == "multipart/form-data":
+		return EncodingMultipart
+	case strings.HasPrefix(mediaType, "text/"):
+		return EncodingText
 	default:
-		return "nil"
+		return EncodingBinary
 	}
 }
 
-// Helper function to generate example values for request/response
-func (g *OperationGenerator) generateExampleResponse(responses map[string]*openapi3.ResponseRef) string {
-	// Look for 200 or 201 response first
-	for _, statusCode := range []string{"200", "201"} {
-		if resp, ok := responses[statusCode]; ok {
-			for _, content := range resp.Value.Content {
-				if content.Schema != nil {
-					return g.generateExampleValue(content.Schema)
-				}
-			}
+// preferredRequestMediaType picks the media type a request body is sent as:
+// JSON when offered, then forms, multipart, text and finally anything else
+func preferredRequestMediaType(content openapi3.Content) string {
+	rank := func(mediaType string) int {
+		switch bodyEncoding(mediaType) {
+		case EncodingJSON:
+			return 0
+		case EncodingForm:
+			return 1
+		case EncodingMultipart:
+			return 2
+		case EncodingText:
+			return 3
+		}
+		if mediaType == "application/octet-stream" {
+			return 4
 		}
+		return 5
+	}
+
+	types := mediaTypes(content)
+	sort.SliceStable(types, func(i, j int) bool {
+		return rank(types[i]) < rank(types[j])
+	})
+	if len(types) == 0 {
+		return ""
 	}
+	return types[0]
+}
+
+func isJSONMediaType(mediaType string) bool {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
 
-	// If no successful response found, try any response
-	for _, resp := range responses {
-		if resp.Value == nil {
+// parseResponse types the body of a response. Inline schemas that need a
+// type of their own are hoisted into models named after the operation.
+func (g *OperationGenerator) parseResponse(operationName, status string, responseRef *openapi3.ResponseRef) (*Response, error) {
+	response := &Response{
+		StatusCode: status,
+		IsDefault:  status == "default",
+		IsError:    isErrorStatus(status),
+		Condition:  statusCondition(status),
+	}
+
+	resp := responseRef.Value
+	if resp.Description != nil {
+		response.Description = *resp.Description
+	}
+
+	// Only JSON bodies are decoded into typed fields
+	for _, mt := range mediaTypes(resp.Content) {
+		content := resp.Content[mt]
+		if !isJSONMediaType(mt) || content.Schema == nil || isBinarySchema(content.Schema) {
 			continue
 		}
-		for _, content := range resp.Value.Content {
-			if content.Schema != nil {
-				return g.generateExampleValue(content.Schema)
+
+		goType := g.goType(content.Schema, operationName+utils.ToCamelCase(status)+"Response")
+		response.MediaType = mt
+		response.ValueType = goType
+		response.Type = goType
+		if isScalarType(goType) {
+			response.Type = "*" + goType
+		}
+		response.Field = "JSON" + utils.ToCamelCase(status)
+		break
+	}
+
+	// Event streams are decoded event by event, with the schema describing
+	// a single event
+	if response.Field == "" {
+		for _, mt := range mediaTypes(resp.Content) {
+			kind := streamKind(mt)
+			if kind == "" {
+				continue
+			}
+
+			response.MediaType = mt
+			response.Stream = kind
+			response.ValueType = streamType(kind)
+			if schema := resp.Content[mt].Schema; schema != nil {
+				response.ValueType = g.goType(schema, operationName+"Event")
 			}
+			break
 		}
 	}
 
-	return "nil"
-}
-func (g *OperationGenerator) parseResponse(status string, responseRef *openapi3.ResponseRef) (*Response, error) {
-	if responseRef.Value == nil {
-		return &Response{
-			StatusCode:  status,
-			Type:        "error",
-			MediaType:   "application/json",
-			Description: "Error response",
-		}, nil
+	// Anything else, such as images, PDFs or CSV, is left for the caller to
+	// read
+	if response.Field == "" && response.Stream == "" && len(resp.Content) > 0 && !response.IsError {
+		response.Raw = true
+		response.MediaType = mediaTypes(resp.Content)[0]
 	}
 
-	resp := responseRef.Value
+	return response, nil
+}
 
-	// Default response type if no content is specified
-	responseType := "void"
-	mediaType := "application/json"
-
-	if resp.Content != nil && len(resp.Content) > 0 {
-		// Find the first available content type (prefer JSON)
-		for mt, content := range resp.Content {
-			if content.Schema != nil {
-				goType, _ := g.typeMapper.ToGoType(content.Schema)
-				return &Response{
-					StatusCode:  status,
-					Type:        goType,
-					MediaType:   mt,
-					Description: *resp.Description,
-				}, nil
+// isBinarySchema reports whether a schema describes raw bytes rather than
+// a JSON value
+func isBinarySchema(schema *openapi3.SchemaRef) bool {
+	return schema != nil && schema.Value != nil &&
+		schema.Value.Type.Is("string") && schema.Value.Format == "binary"
+}
+
+// rawResponses reports whether an operation returns the body of any of its
+// successful responses unread, and if so the media types it accepts
+func rawResponses(op *openapi3.Operation, responses []Response) (bool, string) {
+	var raw bool
+	var accept []string
+	seen := make(map[string]bool)
+	for _, r := range responses {
+		if !r.Raw && r.Field == "" {
+			continue
+		}
+		raw = raw || r.Raw
+		for _, mt := range mediaTypes(op.Responses.Value(r.StatusCode).Value.Content) {
+			if !seen[mt] && (r.Raw || isJSONMediaType(mt)) {
+				seen[mt] = true
+				accept = append(accept, mt)
 			}
-			mediaType = mt
 		}
 	}
+	if !raw {
+		return false, ""
+	}
+	return true, strings.Join(accept, ", ")
+}
 
-	// Return a default response if no schema is found
-	return &Response{
-		StatusCode:  status,
-		Type:        responseType,
-		MediaType:   mediaType,
-		Description: *resp.Description,
-	}, nil
+// isErrorStatus reports whether a documented status code, or range of codes
+// like 4XX, is a client or server error
+func isErrorStatus(status string) bool {
+	return len(status) == 3 && (status[0] == '4' || status[0] == '5')
+}
+
+// statusCondition returns the Go condition on resp.StatusCode selecting a
+// documented response, e.g. "resp.StatusCode/100 == 2" for 2XX. The default
+// response has no condition.
+func statusCondition(status string) string {
+	switch {
+	case status == "default":
+		return ""
+	case strings.HasSuffix(strings.ToUpper(status), "XX"):
+		return fmt.Sprintf("resp.StatusCode/100 == %c", status[0])
+	default:
+		return "resp.StatusCode == " + status
+	}
+}
+
+// sortResponses orders responses so that exact status codes are matched
+// before ranges and the default response comes last
+func sortResponses(responses []Response) {
+	rank := func(r Response) int {
+		switch {
+		case r.IsDefault:
+			return 2
+		case strings.HasSuffix(strings.ToUpper(r.StatusCode), "XX"):
+			return 1
+		}
+		return 0
+	}
+	sort.SliceStable(responses, func(i, j int) bool {
+		if rank(responses[i]) != rank(responses[j]) {
+			return rank(responses[i]) < rank(responses[j])
+		}
+		return responses[i].StatusCode < responses[j].StatusCode
+	})
 }
 
 func (g *OperationGenerator) generateAPIClient(operations []*Operation) error {
 	data := struct {
-		PackageName string
-		Operations  []*Operation
-		Config      *config.Config
+		PackageName     string
+		Operations      []*Operation
+		SecuritySchemes []SecurityScheme
+		Pagination      map[string]bool // kinds of pagination used
+		Config          *config.Config
 	}{
-		PackageName: g.config.PackageName,
-		Operations:  operations,
-		Config:      g.config,
+		PackageName:     g.config.PackageName,
+		Operations:      operations,
+		SecuritySchemes: g.securitySchemes,
+		Pagination:      paginationKinds(g.operations),
+		Config:          g.config,
 	}
 
 	content, err := g.templates.Execute("client", data)
@@ -401,7 +853,7 @@ func (g *OperationGenerator) generateAPIClient(operations []*Operation) error {
 	}
 
 	filename := filepath.Join(g.config.OutputDir, "client.go")
-	return utils.WriteFile(filename, content)
+	return g.output.write(filename, content)
 }
 
 func (g *OperationGenerator) generateOperationFile(operation *Operation, outputDir string) error {
@@ -421,7 +873,11 @@ func (g *OperationGenerator) generateOperationFile(operation *Operation, outputD
 	}
 
 	filename := filepath.Join(outputDir, strings.ToLower(operation.Name)+".go")
-	return utils.WriteFile(filename, content)
+	if err := g.output.write(filename, content); err != nil {
+		return err
+	}
+	g.sources.add(filename, operation.Source.Pointer)
+	return nil
 }
 
 func (g *OperationGenerator) generateOperationName(method, path string, op *openapi3.Operation) string {
@@ -450,13 +906,17 @@ func (g *OperationGenerator) generateClientFile() error {
 	g.logger.Debug("Generating client file")
 
 	data := struct {
-		PackageName string
-		Operations  []*Operation
-		Config      *config.Config
+		PackageName     string
+		Operations      []*Operation
+		SecuritySchemes []SecurityScheme
+		Pagination      map[string]bool // kinds of pagination used
+		Config          *config.Config
 	}{
-		PackageName: g.config.PackageName,
-		Operations:  g.operations,
-		Config:      g.config,
+		PackageName:     g.config.PackageName,
+		Operations:      g.operations,
+		SecuritySchemes: g.securitySchemes,
+		Pagination:      paginationKinds(g.operations),
+		Config:          g.config,
 	}
 
 	content, err := g.templates.Execute("client", data)
@@ -468,7 +928,7 @@ func (g *OperationGenerator) generateClientFile() error {
 	filename := filepath.Join(g.config.OutputDir, "client.go")
 	g.logger.Debug("Writing client file to: %s", filename)
 
-	if err := utils.WriteFile(filename, content); err != nil {
+	if err := g.output.write(filename, content); err != nil {
 		return errors.Wrap(errors.ErrCodeFileSystemError,
 			"failed to write client file", err)
 	}
@@ -481,6 +941,12 @@ func (g *OperationGenerator) GetOperations() []*Operation {
 	return g.operations
 }
 
+// InlineTypes returns the inline schemas of responses hoisted since the last
+// call, which are generated as models
+func (g *OperationGenerator) InlineTypes() []InlineType {
+	return g.typeMapper.TakeInlineTypes()
+}
+
 func (g *OperationGenerator) generateParamValidation(param *openapi3.Parameter) string {
 	var validations []string
 
@@ -516,35 +982,85 @@ func (g *OperationGenerator) generateParamValidation(param *openapi3.Parameter)
 	return ""
 }
 
-func (g *OperationGenerator) getZeroValue(goType string) string {
+// exampleValue returns a Go expression of type goType for the generated
+// tests, using the example or first enum value of the schema where it fits.
+// Its imports are recorded for the test. An empty expression means that no
+// value could be made up.
+func (g *OperationGenerator) exampleValue(schema *openapi3.SchemaRef, goType string) string {
 	switch goType {
-	case "string":
-		return `""`
+	case "string", "interface{}":
+		if s, ok := exampleLiteral(schema).(string); ok {
+			return strconv.Quote(s)
+		}
+		return `"example"`
 	case "int", "int32", "int64", "float32", "float64":
-		return "0"
+		return "1"
 	case "bool":
-		return "false"
-	default:
-		return "nil"
+		return "true"
+	case "[]byte":
+		return `[]byte("example")`
+	case "time.Time":
+		g.testImports["time"] = true
+		return "time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)"
 	}
-}
 
-func (g *OperationGenerator) getExampleValue(schema *openapi3.SchemaRef) string {
-	if schema != nil && schema.Value != nil {
-		if schema.Value.Example != nil {
-			return fmt.Sprintf("%#v", schema.Value.Example)
-		}
+	if schema == nil || schema.Value == nil {
+		return ""
+	}
 
-		switch schema.Value.Type.Slice()[0] {
-		case "string":
-			return `"example"`
-		case "integer":
-			return "1"
-		case "number":
-			return "1.0"
-		case "boolean":
-			return "true"
+	// Slices and maps get an item, since empty ones count as unset in paths.
+	// Structs are left empty, named scalars such as enums take an untyped
+	// constant.
+	if strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[") || g.typeMapper.IsStructType(schema) {
+		_, imports := g.typeMapper.ToGoType(schema)
+		for _, imp := range imports {
+			g.testImports[imp] = true
+		}
+		item := ""
+		if items := schema.Value.Items; items != nil && schema.Value.Type.Is("array") {
+			itemType, _ := g.typeMapper.ToGoType(items)
+			item = g.exampleValue(items, itemType)
+		} else if values := schema.Value.AdditionalProperties.Schema; values != nil {
+			valueType, _ := g.typeMapper.ToGoType(values)
+			if value := g.exampleValue(values, valueType); value != "" {
+				item = `"example": ` + value
+			}
+		}
+		return goType + "{" + item + "}"
+	}
+	switch v := exampleLiteral(schema).(type) {
+	case string:
+		return strconv.Quote(v)
+	case float64:
+		if schema.Value.Type.Is("integer") && v != float64(int64(v)) {
+			break
 		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
 	}
-	return "nil"
+	switch {
+	case schema.Value.Type.Is("string"):
+		return `"example"`
+	case schema.Value.Type.Is("integer"), schema.Value.Type.Is("number"):
+		return "1"
+	case schema.Value.Type.Is("boolean"):
+		return "true"
+	}
+	return ""
+}
+
+// exampleLiteral returns the example of a schema, or else its first enum
+// value, as decoded from the spec
+func exampleLiteral(schema *openapi3.SchemaRef) interface{} {
+	if schema == nil || schema.Value == nil {
+		return nil
+	}
+	if schema.Value.Example != nil {
+		return schema.Value.Example
+	}
+	if len(schema.Value.Enum) > 0 {
+		return schema.Value.Enum[0]
+	}
+	return nil
 }
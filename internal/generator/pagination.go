@@ -0,0 +1,264 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/chashtager/opensdkraft/internal/config"
+	"github.com/chashtager/opensdkraft/internal/errors"
+	"github.com/chashtager/opensdkraft/internal/utils"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// paginationExtension describes how a list operation pages through its
+// results, e.g.
+//
+//	x-pagination:
+//	  type: cursor          # cursor, offset or link
+//	  cursorParam: cursor   # parameter taking the cursor of the next page
+//	  nextCursor: meta.next # response field holding that cursor
+//	  offsetParam: offset   # parameter taking the offset of the next page
+//	  limitParam: limit     # parameter limiting the page size
+//	  items: data           # response field holding the items, if the body is not the array itself
+const paginationExtension = "x-pagination"
+
+// Kinds of pagination
+const (
+	PaginationCursor = "cursor"
+	PaginationOffset = "offset"
+	PaginationLink   = "link" // RFC 8288 Link header with rel="next"
+)
+
+// Pagination is the pagination of a list operation
+type Pagination struct {
+	Kind          string
+	ItemType      string
+	ResponseField string    // field of the operation's response holding the page
+	Items         FieldPath // items of a page
+	NextCursor    FieldPath
+	CursorParam   *Parameter
+	OffsetParam   *Parameter
+	LimitParam    *Parameter
+}
+
+// FieldPath reads a field of a page. Expr may only be evaluated if Guard,
+// which checks the pointers and nullable values along the way, holds.
+type FieldPath struct {
+	Guard string
+	Expr  string
+	Type  string // type of Expr
+}
+
+// parsePagination reads the x-pagination extension of an operation, whose
+// parameters and responses have already been parsed
+func (g *OperationGenerator) parsePagination(op *openapi3.Operation, operation *Operation) (*Pagination, error) {
+	value, ok := op.Extensions[paginationExtension]
+	if !ok {
+		return nil, nil
+	}
+
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, paginationError("must be an object")
+	}
+	option := func(name string) string {
+		s, _ := fields[name].(string)
+		return s
+	}
+
+	if operation.Stream != nil {
+		return nil, paginationError("streamed responses cannot be paginated")
+	}
+
+	pagination := &Pagination{Kind: option("type")}
+	if pagination.Kind == "" {
+		// Infer the kind from the parameters that are given
+		switch {
+		case option("cursorParam") != "":
+			pagination.Kind = PaginationCursor
+		case option("offsetParam") != "":
+			pagination.Kind = PaginationOffset
+		default:
+			pagination.Kind = PaginationLink
+		}
+	}
+
+	// Pages are read from the first successful JSON response
+	var page *Response
+	for i := range operation.Responses {
+		if r := &operation.Responses[i]; r.Field != "" && !r.IsError && !r.IsDefault {
+			page = r
+			break
+		}
+	}
+	if page == nil {
+		return nil, paginationError("operation has no successful JSON response")
+	}
+	pagination.ResponseField = page.Field
+	schema := op.Responses.Value(page.StatusCode).Value.Content[page.MediaType].Schema
+
+	root := FieldPath{Expr: "page." + page.Field, Type: page.Type}
+	if page.IsPointer() {
+		root = FieldPath{Guard: "page." + page.Field + " != nil", Expr: "page." + page.Field, Type: page.ValueType}
+	}
+
+	items, itemsSchema, err := g.fieldPath(root, schema, option("items"))
+	if err != nil {
+		return nil, paginationError(fmt.Sprintf("items: %v", err))
+	}
+	if itemsSchema == nil || !itemsSchema.Value.Type.Is("array") || !strings.HasPrefix(items.Type, "[]") {
+		return nil, paginationError("items must be an array")
+	}
+	pagination.Items = items
+	pagination.ItemType = strings.TrimPrefix(items.Type, "[]")
+
+	param := func(option string, required bool) (*Parameter, error) {
+		name := fields[option]
+		if name == nil {
+			if required {
+				return nil, paginationError(fmt.Sprintf("%s is required for %s pagination", option, pagination.Kind))
+			}
+			return nil, nil
+		}
+		for i := range operation.Parameters {
+			if p := &operation.Parameters[i]; p.JSONName == name && p.Location == "query" {
+				return p, nil
+			}
+		}
+		return nil, paginationError(fmt.Sprintf("%s %v is not a query parameter of the operation", option, name))
+	}
+
+	if pagination.LimitParam, err = param("limitParam", false); err != nil {
+		return nil, err
+	}
+	if pagination.LimitParam != nil && !isIntegerType(pagination.LimitParam.ValueType()) {
+		return nil, paginationError("limitParam must be an integer")
+	}
+
+	switch pagination.Kind {
+	case PaginationCursor:
+		if pagination.CursorParam, err = param("cursorParam", true); err != nil {
+			return nil, err
+		}
+		if option("nextCursor") == "" {
+			return nil, paginationError("nextCursor is required for cursor pagination")
+		}
+		next, _, err := g.fieldPath(root, schema, option("nextCursor"))
+		if err != nil {
+			return nil, paginationError(fmt.Sprintf("nextCursor: %v", err))
+		}
+		if next.Type != pagination.CursorParam.ValueType() {
+			return nil, paginationError(fmt.Sprintf("nextCursor is a %s but cursorParam takes a %s",
+				next.Type, pagination.CursorParam.ValueType()))
+		}
+		pagination.NextCursor = next
+	case PaginationOffset:
+		if pagination.OffsetParam, err = param("offsetParam", true); err != nil {
+			return nil, err
+		}
+		if !isIntegerType(pagination.OffsetParam.ValueType()) {
+			return nil, paginationError("offsetParam must be an integer")
+		}
+	case PaginationLink:
+	default:
+		return nil, paginationError(fmt.Sprintf("unknown type %q", pagination.Kind))
+	}
+
+	return pagination, nil
+}
+
+// fieldPath follows a dot-separated path of properties from a page, using
+// the same field types as the generated models. An empty path is the page
+// itself.
+func (g *OperationGenerator) fieldPath(root FieldPath, schema *openapi3.SchemaRef, path string) (FieldPath, *openapi3.SchemaRef, error) {
+	current := root
+	if path == "" {
+		return current, schema, nil
+	}
+
+	var guards []string
+	if current.Guard != "" {
+		guards = append(guards, current.Guard)
+	}
+
+	for i, name := range strings.Split(path, ".") {
+		if schema == nil || schema.Value == nil || schema.Value.Properties[name] == nil {
+			return FieldPath{}, nil, fmt.Errorf("no property %s in %s", name, path)
+		}
+		// Properties are only fields of structs, not of maps or unions. The
+		// page has the type of the response; nested objects with properties
+		// are hoisted into models.
+		if (i == 0 && !isScalarType(current.Type)) || !g.typeMapper.IsStructType(schema) || isUnion(schema.Value) {
+			return FieldPath{}, nil, fmt.Errorf("cannot read %s in %s from a value that is not a struct", name, path)
+		}
+
+		required := utils.StringContains(schema.Value.Required, name)
+		schema = schema.Value.Properties[name]
+		goType := g.goType(schema, "")
+		expr := current.Expr + "." + g.typeMapper.ToGoName(name)
+
+		// Mirrors the field types chosen by ModelGenerator.preparePropertyData
+		switch {
+		case schema.Value.Nullable && g.config.CodeStyle.NullableStyle == config.NullableStyleGeneric:
+			guards = append(guards, expr+".HasValue()")
+			current = FieldPath{Expr: expr + ".Value()", Type: goType}
+		case g.typeMapper.IsStructType(schema):
+			guards = append(guards, expr+" != nil")
+			current = FieldPath{Expr: expr, Type: goType}
+		case isScalarType(goType) && (schema.Value.Nullable || (!required && g.config.CodeStyle.UsePointers)):
+			guards = append(guards, expr+" != nil")
+			current = FieldPath{Expr: "*" + expr, Type: goType}
+		default:
+			current = FieldPath{Expr: expr, Type: goType}
+		}
+	}
+
+	current.Guard = strings.Join(guards, " && ")
+	return current, schema, nil
+}
+
+func isIntegerType(goType string) bool {
+	return goType == "int" || goType == "int32" || goType == "int64"
+}
+
+func paginationError(msg string) error {
+	return errors.InvalidInput(paginationExtension + ": " + msg)
+}
+
+// paginationKinds returns the kinds of pagination used by the operations
+func paginationKinds(operations []*Operation) map[string]bool {
+	kinds := make(map[string]bool)
+	for _, op := range operations {
+		if op.Pagination != nil {
+			kinds[op.Pagination.Kind] = true
+		}
+	}
+	return kinds
+}
+
+func (g *OperationGenerator) generatePaginationFile() error {
+	data := struct {
+		PackageName string
+		Kinds       map[string]bool
+		Config      *config.Config
+	}{
+		PackageName: g.config.PackageName,
+		Kinds:       paginationKinds(g.operations),
+		Config:      g.config,
+	}
+
+	content, err := g.templates.Execute("pagination", data)
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeTemplateError,
+			"failed to execute pagination template", err)
+	}
+
+	filename := filepath.Join(g.config.OutputDir, "pagination.go")
+	if err := g.output.write(filename, content); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError,
+			"failed to write pagination file", err)
+	}
+
+	return nil
+}
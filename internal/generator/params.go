@@ -0,0 +1,44 @@
+package generator
+
+import (
+	"path/filepath"
+
+	"github.com/chashtager/opensdkraft/internal/config"
+	"github.com/chashtager/opensdkraft/internal/errors"
+)
+
+// hasParameters reports whether any of the operations takes parameters
+func hasParameters(operations []*Operation) bool {
+	for _, op := range operations {
+		if len(op.Parameters) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// generateParamsFile writes the serialization of path, query, header and
+// cookie parameters shared by the operations
+func (g *OperationGenerator) generateParamsFile() error {
+	data := struct {
+		PackageName string
+		Config      *config.Config
+	}{
+		PackageName: g.config.PackageName,
+		Config:      g.config,
+	}
+
+	content, err := g.templates.Execute("params", data)
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeTemplateError,
+			"failed to execute params template", err)
+	}
+
+	filename := filepath.Join(g.config.OutputDir, "params.go")
+	if err := g.output.write(filename, content); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError,
+			"failed to write params file", err)
+	}
+
+	return nil
+}
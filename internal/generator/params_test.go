@@ -0,0 +1,47 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// TestGenerateParameterStyles generates an SDK from a spec with parameters
+// of every style. Generation type-checks the SDK's tests, which are then run
+// if the SDK's dependencies are in the module cache.
+func TestGenerateParameterStyles(t *testing.T) {
+	dir := generate(t, filepath.Join("testdata", "styles.yaml"))
+	files := readTree(t, dir)
+
+	op := files["getstyles.go"]
+	for _, style := range []string{"simple", "label", "matrix", "form", "spaceDelimited", "pipeDelimited", "deepObject"} {
+		if !bytes.Contains(op, []byte(strconv.Quote(style))) {
+			t.Errorf("no parameter serialized with style %s", style)
+		}
+	}
+	for _, name := range []string{"params_test.go", "getstyles_test.go"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("%s not generated", name)
+		}
+	}
+
+	if testing.Short() {
+		t.Skip("not running the tests of the generated SDK in short mode")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not found, not running the tests of the generated SDK")
+	}
+	cmd := exec.Command("go", "test", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOPROXY=off")
+	out, err := cmd.CombinedOutput()
+	if bytes.Contains(out, []byte("GOPROXY=off")) {
+		t.Skipf("dependencies of the generated SDK not in the module cache:\n%s", out)
+	}
+	if err != nil {
+		t.Fatalf("tests of the generated SDK failed: %v\n%s", err, out)
+	}
+}
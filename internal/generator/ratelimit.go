@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+
+	"github.com/chashtager/opensdkraft/internal/config"
+	"github.com/chashtager/opensdkraft/internal/errors"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// rateLimitExtension sets a token bucket on the document, a tag or an
+// operation, e.g. x-rate-limit: {requestsPerSecond: 10, burst: 20}
+const rateLimitExtension = "x-rate-limit"
+
+// RateLimit is a token bucket declared with the x-rate-limit extension
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// Limit renders RequestsPerSecond as a Go constant
+func (r RateLimit) Limit() string {
+	return strconv.FormatFloat(r.RequestsPerSecond, 'g', -1, 64)
+}
+
+// TagRateLimit is the bucket shared by the operations of a tag
+type TagRateLimit struct {
+	Tag string
+	RateLimit
+}
+
+// RateLimits are the buckets declared outside of operations
+type RateLimits struct {
+	Global *RateLimit
+	Tags   []TagRateLimit
+}
+
+// SetRateLimits records the rate limits declared on the document and its tags
+func (g *OperationGenerator) SetRateLimits(doc *openapi3.T) error {
+	g.rateLimits = RateLimits{}
+
+	global, err := parseRateLimit(doc.Extensions)
+	if err != nil {
+		return &ValidationError{
+			Category: "RateLimit",
+			Path:     "document",
+			Source:   Source{Pointer: jsonPointer(rateLimitExtension)},
+			Errors:   []string{err.Error()},
+		}
+	}
+	g.rateLimits.Global = global
+
+	for i, tag := range doc.Tags {
+		if tag == nil {
+			continue
+		}
+		limit, err := parseRateLimit(tag.Extensions)
+		if err != nil {
+			return &ValidationError{
+				Category: "RateLimit",
+				Path:     "tag " + tag.Name,
+				Source:   Source{Pointer: jsonPointer("tags", strconv.Itoa(i), rateLimitExtension)},
+				Errors:   []string{err.Error()},
+			}
+		}
+		if limit != nil {
+			g.rateLimits.Tags = append(g.rateLimits.Tags, TagRateLimit{Tag: tag.Name, RateLimit: *limit})
+		}
+	}
+
+	return nil
+}
+
+// parseRateLimit reads the x-rate-limit extension, if present. The burst
+// defaults to one second's worth of requests.
+func parseRateLimit(extensions map[string]interface{}) (*RateLimit, error) {
+	value, ok := extensions[rateLimitExtension]
+	if !ok {
+		return nil, nil
+	}
+
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object with requestsPerSecond and burst", rateLimitExtension)
+	}
+
+	rps, ok := fields["requestsPerSecond"].(float64)
+	if !ok || rps <= 0 {
+		return nil, fmt.Errorf("%s requestsPerSecond must be a positive number", rateLimitExtension)
+	}
+	limit := &RateLimit{
+		RequestsPerSecond: rps,
+		Burst:             int(math.Max(math.Ceil(rps), 1)),
+	}
+
+	if burst, ok := fields["burst"]; ok {
+		n, ok := burst.(float64)
+		if !ok || n < 1 || n != math.Trunc(n) {
+			return nil, fmt.Errorf("%s burst must be a positive integer", rateLimitExtension)
+		}
+		limit.Burst = int(n)
+	}
+
+	return limit, nil
+}
+
+func (g *OperationGenerator) generateRateLimitFile() error {
+	data := struct {
+		PackageName string
+		RateLimits  RateLimits
+		Operations  []*Operation
+		Config      *config.Config
+	}{
+		PackageName: g.config.PackageName,
+		RateLimits:  g.rateLimits,
+		Operations:  g.operations,
+		Config:      g.config,
+	}
+
+	content, err := g.templates.Execute("ratelimit", data)
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeTemplateError,
+			"failed to execute ratelimit template", err)
+	}
+
+	filename := filepath.Join(g.config.OutputDir, "ratelimit.go")
+	if err := g.output.write(filename, content); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError,
+			"failed to write rate limit file", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Markers of the regions of generated files that hold hand-written code.
+// The text after the begin marker names the region, and may be repeated
+// after the end marker.
+const (
+	keepBegin = "// sdkraft:keep begin"
+	keepEnd   = "// sdkraft:keep end"
+)
+
+// customFileSuffix ends the names of hand-written files, which are never
+// generated, overwritten or removed
+const customFileSuffix = "_custom.go"
+
+// keptRegions returns the bodies of the regions of content by name
+func keptRegions(content []byte) (map[string][]byte, error) {
+	regions := make(map[string][]byte)
+	var duplicate string
+	err := scanRegions(content, func(name string, body []byte) {
+		if _, ok := regions[name]; ok {
+			duplicate = name
+		}
+		regions[name] = body
+	}, nil)
+	if err == nil && duplicate != "" {
+		err = fmt.Errorf("region %q appears more than once", duplicate)
+	}
+	return regions, err
+}
+
+// mergeRegions returns generated with the bodies of its regions taken from
+// existing, the previous version of the file. Regions of existing that
+// generated no longer has are returned by name.
+func mergeRegions(generated, existing []byte) ([]byte, []string, error) {
+	kept, err := keptRegions(existing)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(kept) == 0 {
+		return generated, nil, nil
+	}
+
+	var merged bytes.Buffer
+	err = scanRegions(generated, func(name string, body []byte) {
+		if existing, ok := kept[name]; ok {
+			body = existing
+			delete(kept, name)
+		}
+		merged.Write(body)
+	}, func(line []byte) {
+		merged.Write(line)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("generated code: %w", err)
+	}
+
+	var dropped []string
+	for name, body := range kept {
+		if len(bytes.TrimSpace(body)) > 0 {
+			dropped = append(dropped, name)
+		}
+	}
+	sort.Strings(dropped)
+	return merged.Bytes(), dropped, nil
+}
+
+// stripRegions returns content with the bodies of its regions left out, so
+// that hand-written code does not count as a change to the generated code
+func stripRegions(content []byte) []byte {
+	var stripped bytes.Buffer
+	err := scanRegions(content, func(string, []byte) {}, func(line []byte) {
+		stripped.Write(line)
+	})
+	if err != nil {
+		return content
+	}
+	return stripped.Bytes()
+}
+
+// scanRegions calls region with the name and body of each region of
+// content, and line with every other line, including the markers
+func scanRegions(content []byte, region func(name string, body []byte), line func(line []byte)) error {
+	var (
+		name   string
+		body   []byte
+		inside bool
+	)
+	for n, rest := 1, content; len(rest) > 0; n++ {
+		end := bytes.IndexByte(rest, '\n') + 1
+		if end == 0 {
+			end = len(rest)
+		}
+		current := rest[:end]
+		rest = rest[end:]
+
+		text := strings.TrimSpace(string(current))
+		switch {
+		case strings.HasPrefix(text, keepBegin):
+			if inside {
+				return fmt.Errorf("line %d: region %q begins inside region %q", n, regionName(text, keepBegin), name)
+			}
+			name, body, inside = regionName(text, keepBegin), nil, true
+		case strings.HasPrefix(text, keepEnd):
+			if !inside {
+				return fmt.Errorf("line %d: region ends without beginning", n)
+			}
+			if closing := regionName(text, keepEnd); closing != "" && closing != name {
+				return fmt.Errorf("line %d: region %q ends as %q", n, name, closing)
+			}
+			region(name, body)
+			inside = false
+		case inside:
+			body = append(body, current...)
+			continue
+		}
+
+		if line != nil {
+			line(current)
+		}
+	}
+	if inside {
+		return fmt.Errorf("region %q does not end", name)
+	}
+	return nil
+}
+
+// regionNames lists regions by name for messages
+func regionNames(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = "(unnamed)"
+		if name != "" {
+			quoted[i] = strconv.Quote(name)
+		}
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// regionName returns the name following a region marker
+func regionName(text, marker string) string {
+	return strings.TrimSpace(strings.TrimPrefix(text, marker))
+}
@@ -0,0 +1,257 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/chashtager/opensdkraft/internal/config"
+	"github.com/chashtager/opensdkraft/internal/errors"
+	"github.com/chashtager/opensdkraft/internal/utils"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Kinds of credentials the generated client can send
+const (
+	SecurityAPIKey = "apiKey"
+	SecurityBasic  = "basic"
+	SecurityBearer = "bearer" // HTTP schemes other than basic
+	SecurityOAuth2 = "oauth2" // OAuth2 and OpenID Connect
+)
+
+// SecurityScheme is a components.securitySchemes entry of the spec
+type SecurityScheme struct {
+	Name        string // key in components.securitySchemes
+	GoName      string
+	Field       string // credentials field holding the scheme's secret
+	Kind        string
+	In          string // header, query or cookie for API keys
+	ParamName   string // header, query or cookie name for API keys
+	AuthScheme  string // scheme sent in the Authorization header, e.g. Bearer
+	TokenURL    string // token endpoint of the OAuth2 client credentials flow
+	RefreshURL  string // endpoint accepting OAuth2 refresh token grants
+	Description string
+}
+
+// SecurityRequirement lists the schemes that must all be applied to satisfy
+// one of an operation's security alternatives. An empty requirement means the
+// operation may be called anonymously.
+type SecurityRequirement []SchemeRequirement
+
+// SchemeRequirement names a security scheme and the OAuth2 scopes an
+// operation needs from it
+type SchemeRequirement struct {
+	Name   string
+	Scopes []string
+}
+
+// SetSecurity records the security schemes and the default requirements of
+// the document, used by operations that do not declare their own.
+func (g *OperationGenerator) SetSecurity(schemes openapi3.SecuritySchemes, global openapi3.SecurityRequirements) error {
+	g.securitySchemes = nil
+	for _, name := range utils.SortedKeys(schemes) {
+		scheme, err := g.parseSecurityScheme(name, schemes[name])
+		if err != nil {
+			return &ValidationError{
+				Category: "Security",
+				Path:     name,
+				Source:   Source{Pointer: jsonPointer("components", "securitySchemes", name)},
+				Errors:   []string{err.Error()},
+			}
+		}
+		g.securitySchemes = append(g.securitySchemes, *scheme)
+	}
+
+	g.globalSecurity = convertSecurityRequirements(global)
+	return nil
+}
+
+func (g *OperationGenerator) parseSecurityScheme(name string, ref *openapi3.SecuritySchemeRef) (*SecurityScheme, error) {
+	if ref == nil || ref.Value == nil {
+		return nil, fmt.Errorf("security scheme is nil")
+	}
+
+	s := ref.Value
+	scheme := &SecurityScheme{
+		Name:        name,
+		GoName:      g.typeMapper.ToGoName(name),
+		Field:       utils.ToLowerCamelCase(name),
+		Description: s.Description,
+	}
+
+	switch s.Type {
+	case "apiKey":
+		if s.In != "header" && s.In != "query" && s.In != "cookie" {
+			return nil, fmt.Errorf("unsupported apiKey location %q", s.In)
+		}
+		scheme.Kind = SecurityAPIKey
+		scheme.In = s.In
+		scheme.ParamName = s.Name
+	case "http":
+		switch strings.ToLower(s.Scheme) {
+		case "basic":
+			scheme.Kind = SecurityBasic
+		case "bearer":
+			scheme.Kind = SecurityBearer
+			scheme.AuthScheme = "Bearer"
+		case "":
+			return nil, fmt.Errorf("http security scheme has no scheme")
+		default:
+			scheme.Kind = SecurityBearer
+			scheme.AuthScheme = utils.ToCamelCase(s.Scheme)
+		}
+	case "oauth2":
+		scheme.Kind = SecurityOAuth2
+		scheme.AuthScheme = "Bearer"
+		scheme.TokenURL, scheme.RefreshURL = oauthTokenURLs(s.Flows)
+	case "openIdConnect":
+		scheme.Kind = SecurityOAuth2
+		scheme.AuthScheme = "Bearer"
+	default:
+		return nil, fmt.Errorf("unsupported security scheme type %q", s.Type)
+	}
+
+	return scheme, nil
+}
+
+// oauthTokenURLs returns the token endpoint of the client credentials flow and
+// the endpoint for refreshing tokens, which is the refreshUrl of any flow or
+// else the tokenUrl of a flow that issues refresh tokens
+func oauthTokenURLs(flows *openapi3.OAuthFlows) (tokenURL, refreshURL string) {
+	if flows == nil {
+		return "", ""
+	}
+	if flows.ClientCredentials != nil {
+		tokenURL = flows.ClientCredentials.TokenURL
+	}
+
+	// Flows that can issue refresh tokens, in order of preference
+	candidates := []*openapi3.OAuthFlow{flows.AuthorizationCode, flows.Password, flows.ClientCredentials}
+	for _, flow := range candidates {
+		if flow != nil && flow.RefreshURL != "" {
+			return tokenURL, flow.RefreshURL
+		}
+	}
+	for _, flow := range candidates {
+		if flow != nil && flow.TokenURL != "" {
+			return tokenURL, flow.TokenURL
+		}
+	}
+	return tokenURL, ""
+}
+
+// operationSecurity returns the security alternatives of an operation, which
+// replace the document's default requirements when declared
+func (g *OperationGenerator) operationSecurity(op *openapi3.Operation) []SecurityRequirement {
+	if op.Security == nil {
+		return g.globalSecurity
+	}
+	return convertSecurityRequirements(*op.Security)
+}
+
+func convertSecurityRequirements(requirements openapi3.SecurityRequirements) []SecurityRequirement {
+	var result []SecurityRequirement
+	for _, requirement := range requirements {
+		schemes := make(SecurityRequirement, 0, len(requirement))
+		for _, name := range utils.SortedKeys(requirement) {
+			schemes = append(schemes, SchemeRequirement{Name: name, Scopes: requirement[name]})
+		}
+		result = append(result, schemes)
+	}
+	return result
+}
+
+// securityLiteral renders security alternatives as a Go literal of the
+// generated [][]securityRequirement type
+func securityLiteral(requirements []SecurityRequirement) string {
+	alternatives := make([]string, len(requirements))
+	for i, requirement := range requirements {
+		schemes := make([]string, len(requirement))
+		for j, scheme := range requirement {
+			scopes := "nil"
+			if len(scheme.Scopes) > 0 {
+				quoted := make([]string, len(scheme.Scopes))
+				for k, scope := range scheme.Scopes {
+					quoted[k] = strconv.Quote(scope)
+				}
+				scopes = "[]string{" + strings.Join(quoted, ", ") + "}"
+			}
+			schemes[j] = "{" + strconv.Quote(scheme.Name) + ", " + scopes + "}"
+		}
+		alternatives[i] = "{" + strings.Join(schemes, ", ") + "}"
+	}
+	return "[][]securityRequirement{" + strings.Join(alternatives, ", ") + "}"
+}
+
+func (g *OperationGenerator) generateAuthFile() error {
+	data := struct {
+		PackageName     string
+		SecuritySchemes []SecurityScheme
+		HasOAuth2       bool
+		HasQueryAPIKey  bool
+		Config          *config.Config
+	}{
+		PackageName:     g.config.PackageName,
+		SecuritySchemes: g.securitySchemes,
+		HasOAuth2:       hasOAuth2(g.securitySchemes),
+		HasQueryAPIKey:  queryAPIKey(g.securitySchemes) != nil,
+		Config:          g.config,
+	}
+
+	content, err := g.templates.Execute("auth", data)
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeTemplateError,
+			"failed to execute auth template", err)
+	}
+
+	filename := filepath.Join(g.config.OutputDir, "auth.go")
+	if err := g.output.write(filename, content); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError,
+			"failed to write auth file", err)
+	}
+
+	if !data.HasOAuth2 {
+		return nil
+	}
+
+	content, err = g.templates.Execute("oauth2", data)
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeTemplateError,
+			"failed to execute oauth2 template", err)
+	}
+
+	filename = filepath.Join(g.config.OutputDir, "oauth2.go")
+	if err := g.output.write(filename, content); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError,
+			"failed to write oauth2 file", err)
+	}
+
+	return nil
+}
+
+// SecuritySchemes returns the security schemes recorded by SetSecurity
+func (g *OperationGenerator) SecuritySchemes() []SecurityScheme {
+	return g.securitySchemes
+}
+
+// hasOAuth2 reports whether any of the schemes is OAuth2 or OpenID Connect
+func hasOAuth2(schemes []SecurityScheme) bool {
+	for _, scheme := range schemes {
+		if scheme.Kind == SecurityOAuth2 {
+			return true
+		}
+	}
+	return false
+}
+
+// queryAPIKey returns the first of the schemes that is an API key sent in
+// the query, or nil if there is none
+func queryAPIKey(schemes []SecurityScheme) *SecurityScheme {
+	for i := range schemes {
+		if schemes[i].Kind == SecurityAPIKey && schemes[i].In == "query" {
+			return &schemes[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/chashtager/opensdkraft/internal/parser"
+	"github.com/chashtager/opensdkraft/internal/utils"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Source locates the element of the spec generated code or a problem comes
+// from
+type Source struct {
+	Pointer string // JSON pointer, e.g. #/paths/~1pets/get
+	File    string // spec file, as given to the generator
+	Line    int    // position in File, 0 if unknown
+	Column  int
+}
+
+// String returns the pointer followed by the file:line:column position, if
+// known, e.g. #/paths/~1pets/get (openapi.yaml:12:5)
+func (s Source) String() string {
+	if s.Line == 0 {
+		return s.Pointer
+	}
+	return fmt.Sprintf("%s (%s:%d:%d)", s.Pointer, s.File, s.Line, s.Column)
+}
+
+// Comment returns the text of the source comment of generated code. The
+// spec file is named without its directory, so that the output does not
+// depend on where it is generated.
+func (s Source) Comment() string {
+	if s.Line == 0 {
+		return s.Pointer
+	}
+	return fmt.Sprintf("%s (%s:%d:%d)", s.Pointer, filepath.Base(s.File), s.Line, s.Column)
+}
+
+// sourceMap records which element of the spec each generated file was
+// produced from, so that problems found in generated code can be reported
+// against the spec, and where the elements of the spec are declared
+type sourceMap struct {
+	root  string
+	files map[string]string // JSON pointers by path relative to root
+
+	locations *parser.Locations
+	schemas   map[*openapi3.Schema]string // JSON pointers of declared schemas
+}
+
+func newSourceMap(root string) *sourceMap {
+	return &sourceMap{
+		root:    root,
+		files:   make(map[string]string),
+		schemas: make(map[*openapi3.Schema]string),
+	}
+}
+
+// load indexes the schemas of doc and the positions of the elements of the
+// spec file it was parsed from
+func (m *sourceMap) load(specFile string, doc *openapi3.T) error {
+	locations, err := parser.LoadLocations(specFile)
+	if err != nil {
+		return err
+	}
+	m.locations = locations
+
+	// Components come first, so that schemas referenced from operations
+	// are attributed to their declaration
+	if components := doc.Components; components != nil {
+		for _, name := range utils.SortedKeys(components.Schemas) {
+			m.indexSchema(jsonPointer("components", "schemas", name), components.Schemas[name])
+		}
+		for _, name := range utils.SortedKeys(components.Parameters) {
+			if param := components.Parameters[name]; param != nil && param.Value != nil {
+				m.indexSchema(jsonPointer("components", "parameters", name, "schema"), param.Value.Schema)
+			}
+		}
+		for _, name := range utils.SortedKeys(components.RequestBodies) {
+			if body := components.RequestBodies[name]; body != nil && body.Value != nil {
+				m.indexContent(jsonPointer("components", "requestBodies", name), body.Value.Content)
+			}
+		}
+		for _, name := range utils.SortedKeys(components.Responses) {
+			if resp := components.Responses[name]; resp != nil && resp.Value != nil {
+				m.indexContent(jsonPointer("components", "responses", name), resp.Value.Content)
+			}
+		}
+	}
+
+	if doc.Paths == nil {
+		return nil
+	}
+	pathMap := doc.Paths.Map()
+	for _, path := range utils.SortedKeys(pathMap) {
+		for i, param := range pathMap[path].Parameters {
+			if param != nil && param.Value != nil {
+				m.indexSchema(jsonPointer("paths", path, "parameters", fmt.Sprint(i), "schema"), param.Value.Schema)
+			}
+		}
+		for _, method := range httpMethods {
+			op := pathMap[path].GetOperation(method)
+			if op == nil {
+				continue
+			}
+			pointer := jsonPointer("paths", path, strings.ToLower(method))
+			for i, param := range op.Parameters {
+				if param != nil && param.Value != nil {
+					m.indexSchema(appendPointer(pointer, "parameters", fmt.Sprint(i), "schema"), param.Value.Schema)
+				}
+			}
+			if body := op.RequestBody; body != nil && body.Value != nil {
+				m.indexContent(appendPointer(pointer, "requestBody"), body.Value.Content)
+			}
+			if op.Responses != nil {
+				responses := op.Responses.Map()
+				for _, status := range utils.SortedKeys(responses) {
+					if resp := responses[status]; resp != nil && resp.Value != nil {
+						m.indexContent(appendPointer(pointer, "responses", status), resp.Value.Content)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// indexContent indexes the schemas of the media types of a body
+func (m *sourceMap) indexContent(pointer string, content openapi3.Content) {
+	for _, mediaType := range utils.SortedKeys(content) {
+		if content[mediaType] != nil {
+			m.indexSchema(appendPointer(pointer, "content", mediaType, "schema"), content[mediaType].Schema)
+		}
+	}
+}
+
+// indexSchema records the pointer of a schema declared at pointer and of
+// the schemas nested in it. References are indexed where they point to.
+func (m *sourceMap) indexSchema(pointer string, schema *openapi3.SchemaRef) {
+	if schema == nil || schema.Value == nil || schema.Ref != "" {
+		return
+	}
+	if _, ok := m.schemas[schema.Value]; ok {
+		return
+	}
+	m.schemas[schema.Value] = pointer
+
+	value := schema.Value
+	for _, name := range utils.SortedKeys(value.Properties) {
+		m.indexSchema(appendPointer(pointer, "properties", name), value.Properties[name])
+	}
+	m.indexSchema(appendPointer(pointer, "items"), value.Items)
+	m.indexSchema(appendPointer(pointer, "additionalProperties"), value.AdditionalProperties.Schema)
+	m.indexSchema(appendPointer(pointer, "not"), value.Not)
+	for _, composition := range []struct {
+		keyword string
+		members openapi3.SchemaRefs
+	}{
+		{"allOf", value.AllOf},
+		{"oneOf", value.OneOf},
+		{"anyOf", value.AnyOf},
+	} {
+		for i, member := range composition.members {
+			m.indexSchema(appendPointer(pointer, composition.keyword, fmt.Sprint(i)), member)
+		}
+	}
+}
+
+// schemaPointer returns the JSON pointer a schema is declared at, or an
+// empty string if it is not declared in the spec file
+func (m *sourceMap) schemaPointer(schema *openapi3.SchemaRef) string {
+	if m == nil || schema == nil || schema.Value == nil {
+		return ""
+	}
+	return m.schemas[schema.Value]
+}
+
+// source returns the Source of the element at pointer
+func (m *sourceMap) source(pointer string) Source {
+	s := Source{Pointer: pointer}
+	if m == nil || m.locations == nil || pointer == "" {
+		return s
+	}
+	if pos, ok := m.locations.Lookup(pointer); ok {
+		s.File = m.locations.File()
+		s.Line = pos.Line
+		s.Column = pos.Column
+	}
+	return s
+}
+
+// locate fills in the positions of errors that have a pointer
+func (m *sourceMap) locate(errs *ValidationErrors) {
+	if m == nil {
+		return
+	}
+	for i := range errs.Errors {
+		if e := &errs.Errors[i]; e.Source.Pointer != "" && e.Source.Line == 0 {
+			e.Source = m.source(e.Source.Pointer)
+		}
+	}
+}
+
+// add records that the file was generated from the spec element at pointer
+func (m *sourceMap) add(filename, pointer string) {
+	if m == nil {
+		return
+	}
+	m.files[m.relative(filename)] = pointer
+}
+
+// lookup returns the JSON pointer of the spec element a file was generated
+// from, or an empty string for files not generated from a single element
+func (m *sourceMap) lookup(filename string) string {
+	if m == nil {
+		return ""
+	}
+	return m.files[m.relative(filename)]
+}
+
+// relative returns a file name within the output directory relative to it,
+// in slash form. Other names are taken to be relative already.
+func (m *sourceMap) relative(filename string) string {
+	if rel, err := filepath.Rel(m.root, filename); err == nil && !strings.HasPrefix(rel, "..") {
+		filename = rel
+	}
+	return filepath.ToSlash(filename)
+}
+
+// jsonPointer returns the JSON pointer, as a URI fragment, to the spec
+// element reached by following tokens, e.g. #/paths/~1pets/get
+func jsonPointer(tokens ...string) string {
+	return appendPointer("#", tokens...)
+}
+
+// appendPointer returns the JSON pointer to the element reached by
+// following tokens from the one at pointer
+func appendPointer(pointer string, tokens ...string) string {
+	escape := strings.NewReplacer("~", "~0", "/", "~1")
+
+	var b strings.Builder
+	b.WriteString(pointer)
+	for _, token := range tokens {
+		b.WriteString("/")
+		b.WriteString(escape.Replace(token))
+	}
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/chashtager/opensdkraft/internal/config"
+	"github.com/chashtager/opensdkraft/internal/errors"
+)
+
+// Kinds of streamed responses
+const (
+	StreamSSE    = "sse"    // Server-Sent Events
+	StreamNDJSON = "ndjson" // newline-delimited JSON
+)
+
+// streamKind returns how a response of the media type is streamed, or ""
+// if it is read as a whole
+func streamKind(mediaType string) string {
+	mediaType, _, _ = strings.Cut(strings.ToLower(mediaType), ";")
+	switch strings.TrimSpace(mediaType) {
+	case "text/event-stream":
+		return StreamSSE
+	case "application/x-ndjson", "application/ndjson", "application/jsonl", "application/x-jsonlines":
+		return StreamNDJSON
+	}
+	return ""
+}
+
+// streamType returns the Go type of the events of a stream without a schema
+func streamType(kind string) string {
+	if kind == StreamSSE {
+		return "string"
+	}
+	return "interface{}"
+}
+
+// hasStreams reports whether any of the operations streams its response
+func hasStreams(operations []*Operation) bool {
+	for _, op := range operations {
+		if op.Stream != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *OperationGenerator) generateStreamFile() error {
+	data := struct {
+		PackageName string
+		Config      *config.Config
+	}{
+		PackageName: g.config.PackageName,
+		Config:      g.config,
+	}
+
+	content, err := g.templates.Execute("stream", data)
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeTemplateError,
+			"failed to execute stream template", err)
+	}
+
+	filename := filepath.Join(g.config.OutputDir, "stream.go")
+	if err := g.output.write(filename, content); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError,
+			"failed to write stream file", err)
+	}
+
+	return nil
+}
@@ -7,15 +7,16 @@ import (
 	"github.com/chashtager/opensdkraft/internal/errors"
 	"github.com/chashtager/opensdkraft/internal/logging"
 	"github.com/chashtager/opensdkraft/internal/utils"
+	"github.com/chashtager/opensdkraft/templates"
 	"go/format"
 	"hash/fnv"
 	"io/fs"
 	"os"
-	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
 	"text/template"
+	"text/template/parse"
 )
 
 type TemplateEngine struct {
@@ -39,7 +40,7 @@ func NewTemplateEngine(cfg *config.Config, logger *logging.Logger) (*TemplateEng
 	// Initialize template function map
 	engine.initFuncMap()
 
-	// Load templates from directory
+	// Load the built-in templates and any overrides
 	if err := engine.loadTemplates(); err != nil {
 		return nil, fmt.Errorf("failed to load templates: %w", err)
 	}
@@ -99,22 +100,50 @@ func (e *TemplateEngine) initFuncMap() {
 		"sub":          func(a, b int) int { return a - b },
 		"mul":          func(a, b int) int { return a * b },
 		"div":          func(a, b int) int { return a / b },
+		"sourceComment": func(s Source) string {
+			if !e.config.CodeStyle.SourceComments || s.Pointer == "" {
+				return ""
+			}
+			return "// source: " + s.Comment()
+		},
 	}
 }
 
 func (e *TemplateEngine) loadTemplates() error {
-	templateDir := "templates"
+	// Start from the built-in templates
+	if err := e.loadTemplatesFrom(templates.FS, "built-in templates"); err != nil {
+		return err
+	}
+	builtIn := len(e.templates)
 
-	// Ensure template directory exists
-	if _, err := os.Stat(templateDir); os.IsNotExist(err) {
-		return fmt.Errorf("template directory does not exist: %s", templateDir)
+	// Templates in the configured directory replace built-in ones by name
+	if dir := e.config.TemplatesDir; dir != "" {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return fmt.Errorf("template directory %s is not accessible: %w", dir, err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("template directory %s is not a directory", dir)
+		}
+		if err := e.loadTemplatesFrom(os.DirFS(dir), dir); err != nil {
+			return err
+		}
 	}
 
-	// Keep track of loaded templates
+	e.logger.Info("Successfully loaded %d templates (%d built in)", len(e.templates), builtIn)
+
+	return nil
+}
+
+// loadTemplatesFrom parses every *.tmpl file of fsys, naming each template
+// after its path without the extension. Templates already loaded under the
+// same name are replaced.
+func (e *TemplateEngine) loadTemplatesFrom(fsys fs.FS, source string) error {
+	// Keep track of templates loaded from this source
 	loadedTemplates := make(map[string]bool)
 
 	// Walk through the template directory recursively
-	err := filepath.WalkDir(templateDir, func(path string, d fs.DirEntry, err error) error {
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return fmt.Errorf("failed to access %s: %w", path, err)
 		}
@@ -129,20 +158,14 @@ func (e *TemplateEngine) loadTemplates() error {
 			return nil
 		}
 
-		// Extract template name relative to the root templateDir
-		relPath, err := filepath.Rel(templateDir, path)
-		if err != nil {
-			return fmt.Errorf("failed to determine relative path for %s: %w", path, err)
-		}
-
-		// Use the filename (without extension) as the template name
-		name := strings.TrimSuffix(relPath, ".tmpl")
+		// Use the slash-separated path (without extension) as the template name
+		name := strings.TrimSuffix(path, ".tmpl")
 
 		if loadedTemplates[name] {
 			return fmt.Errorf("duplicate template name found: %s", name)
 		}
 
-		content, err := os.ReadFile(path)
+		content, err := fs.ReadFile(fsys, path)
 		if err != nil {
 			return fmt.Errorf("failed to read template %s: %w", path, err)
 		}
@@ -151,8 +174,18 @@ func (e *TemplateEngine) loadTemplates() error {
 		if err != nil {
 			return fmt.Errorf("failed to parse template %s: %w", name, err)
 		}
+		// A file of only {{ define }} blocks leaves the template named after
+		// it empty, which would silently generate empty files
+		if tmpl.Tree == nil || parse.IsEmptyTree(tmpl.Tree.Root) {
+			return errors.New(errors.ErrCodeTemplateError,
+				fmt.Sprintf("template %s is empty outside of its define blocks", name))
+		}
 
-		e.logger.Debug("Loaded template: %s from %s", name, path)
+		if _, exists := e.templates[name]; exists {
+			e.logger.Debug("Template %s overridden from %s", name, source)
+		} else {
+			e.logger.Debug("Loaded template: %s from %s", name, source)
+		}
 
 		e.templates[name] = tmpl
 		loadedTemplates[name] = true
@@ -160,28 +193,9 @@ func (e *TemplateEngine) loadTemplates() error {
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to load templates: %w", err)
-	}
-
-	// Check if we loaded at least the minimum required templates
-	requiredTemplates := []string{"model", "client", "operation"}
-	var missingTemplates []string
-	for _, required := range requiredTemplates {
-		normalized := filepath.ToSlash(required)
-		found := false
-		for loaded := range loadedTemplates {
-			if filepath.ToSlash(loaded) == normalized {
-				found = true
-				break
-			}
-		}
-		if !found {
-			missingTemplates = append(missingTemplates, required)
-		}
+		return fmt.Errorf("failed to load templates from %s: %w", source, err)
 	}
 
-	e.logger.Info("Successfully loaded %d templates", len(loadedTemplates))
-
 	return nil
 }
 
@@ -0,0 +1,67 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chashtager/opensdkraft/internal/config"
+	"github.com/chashtager/opensdkraft/internal/logging"
+)
+
+func TestTemplateOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "replaces the built-in template",
+			content: "package {{ .PackageName }}\n",
+		},
+		{
+			name:    "does not parse",
+			content: "{{ if }}",
+			wantErr: "failed to parse template model",
+		},
+		{
+			name:    "only defines other templates",
+			content: "{{ define \"fields\" }}{{ end }}\n",
+			wantErr: "template model is empty outside of its define blocks",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "model.tmpl"), []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			logger, err := logging.NewLogger(filepath.Join(t.TempDir(), "test.log"), logging.ERROR, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer logger.Close()
+
+			engine, err := NewTemplateEngine(&config.Config{TemplatesDir: dir}, logger)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			content, err := engine.Execute("model", struct{ PackageName string }{"api"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != "package api\n" {
+				t.Errorf("model = %q, want the override", content)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "io"
+    "net/http"
+    "petstore-sdk/models"
+)
+// Addpet Add a new pet to the store
+
+// AddpetRequest contains the request body for Addpet,
+// sent as application/json
+type AddpetRequest struct {
+    // Create a new pet in the store
+    Body models.Pet
+}
+
+// encode returns the encoded request body and its content type
+func (r *AddpetRequest) encode() (io.Reader, string, error) {
+    return jsonBody(&r.Body, "application/json")
+}
+
+// AddpetResponse is the response of Addpet. At most one
+// of the typed bodies is set, depending on the status code.
+type AddpetResponse struct {
+    StatusCode int
+    Header     http.Header
+    // JSON200 is the body of a 200 response: Successful operation
+    JSON200 *models.Pet
+}
+
+// Addpet Add a new pet to the store
+func (c *Client) Addpet(
+    ctx context.Context,
+    request *AddpetRequest,
+) (*AddpetResponse, error) {
+
+    path := "/pet"
+
+    // Encode request body
+    var body io.Reader
+    var contentType string
+    if request != nil {
+        if err := beforeSend(request); err != nil {
+            return nil, err
+        }
+        var err error
+        body, contentType, err = request.encode()
+        if err != nil {
+            return nil, fmt.Errorf("failed to encode request body: %w", err)
+        }
+    } else {
+        return nil, fmt.Errorf("request cannot be nil")
+    }
+
+    // Create request
+    req, err := c.newRequest(ctx, "POST", path, body, contentType)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    // Apply the credentials of the first satisfiable security requirement
+    if err := c.authenticate(req, [][]securityRequirement{{{"petstore_auth", []string{"write:pets", "read:pets"}}}}); err != nil {
+        return nil, err
+    }
+
+    // Send request
+    op := operation{
+        name:       "Addpet",
+        tags:       []string{"pet"},
+        idempotent: false,
+    }
+    resp, err := c.do(req, op)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    // Decode the body documented for the status code. Error statuses are
+    // returned as an *APIError carrying the decoded error model.
+    response := &AddpetResponse{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+    switch {
+    case resp.StatusCode == 200:
+        var v models.Pet
+        if err := decodeJSON(resp, &v); err != nil {
+            return nil, err
+        }
+        response.JSON200 = &v
+    case resp.StatusCode == 400:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode == 422:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode >= 400:
+        return nil, newAPIError(resp, nil)
+    }
+
+    if err := afterDecode(response); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
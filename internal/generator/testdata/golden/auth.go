@@ -0,0 +1,102 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+)
+
+// TokenSource supplies the access tokens sent for OAuth2 and OpenID Connect
+// security schemes
+type TokenSource interface {
+    Token(ctx context.Context) (string, error)
+}
+
+// StaticToken is a TokenSource that always returns the same token
+type StaticToken string
+
+// Token implements TokenSource
+func (t StaticToken) Token(context.Context) (string, error) {
+    return string(t), nil
+}
+
+// securityRequirement names a security scheme and the OAuth2 scopes an
+// operation needs from it
+type securityRequirement struct {
+    scheme string
+    scopes []string
+}
+
+// credentials holds the secrets configured for each security scheme
+type credentials struct {
+    apiKey string
+    petstoreAuth TokenSource
+}
+
+// WithApiKey sets the API key sent in the api_key header
+func WithApiKey(key string) ClientOption {
+    return func(c *Client) {
+        c.auth.apiKey = key
+    }
+}
+
+// WithPetstoreAuth sets the source of the access tokens sent as Bearer
+// tokens. Use StaticToken for a token obtained elsewhere.
+func WithPetstoreAuth(ts TokenSource) ClientOption {
+    return func(c *Client) {
+        c.auth.petstoreAuth = ts
+    }
+}
+
+// authenticate applies the credentials of the first security alternative
+// whose schemes are all configured. If none is, the request is sent without
+// credentials and the server decides whether to accept it.
+func (c *Client) authenticate(req *http.Request, alternatives [][]securityRequirement) error {
+    for _, requirement := range alternatives {
+        if !c.hasCredentials(requirement) {
+            continue
+        }
+        for _, scheme := range requirement {
+            if err := c.applyCredentials(req, scheme); err != nil {
+                return err
+            }
+        }
+        return nil
+    }
+    return nil
+}
+
+// hasCredentials reports whether every scheme of a requirement is configured
+func (c *Client) hasCredentials(requirement []securityRequirement) bool {
+    for _, r := range requirement {
+        switch r.scheme {
+        case "api_key":
+            if c.auth.apiKey == "" {
+                return false
+            }
+        case "petstore_auth":
+            if c.auth.petstoreAuth == nil {
+                return false
+            }
+        default:
+            return false
+        }
+    }
+    return true
+}
+
+// applyCredentials adds the credentials of a scheme to the request. OAuth2
+// token sources receive the required scopes through the context.
+func (c *Client) applyCredentials(req *http.Request, r securityRequirement) error {
+    switch r.scheme {
+    case "api_key":
+        req.Header.Set("api_key", c.auth.apiKey)
+    case "petstore_auth":
+        token, err := c.auth.petstoreAuth.Token(ContextWithScopes(req.Context(), r.scopes...))
+        if err != nil {
+            return fmt.Errorf("failed to get petstore_auth token: %w", err)
+        }
+        req.Header.Set("Authorization", "Bearer "+token)
+    }
+    return nil
+}
@@ -0,0 +1,421 @@
+package myapi
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "io"
+    "math"
+    "math/rand"
+    "mime/multipart"
+    "net/http"
+    "net/url"
+    "path/filepath"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// ClientOption allows customizing the API client
+type ClientOption func(*Client)
+
+// Client represents the API client
+type Client struct {
+    baseURL     string
+    httpClient  *http.Client
+    auth        credentials
+    retryConfig *RetryConfig
+    rateLimits  rateLimits
+    userAgent   string
+    middleware  []Middleware
+    doer        Doer // httpClient wrapped in the middleware
+}
+
+// operation describes the API operation a request belongs to
+type operation struct {
+    name       string // name of the client method
+    tags       []string
+    idempotent bool // safe to retry
+}
+// RetryConfig holds the retry settings. Requests are retried after network
+// errors and 429 or 5xx responses, provided their operation is idempotent and
+// their body can be sent again.
+type RetryConfig struct {
+    MaxRetries int
+    // RetryDelay is the backoff before the first retry. It grows by
+    // BackoffFactor for every further retry, up to MaxRetryDelay, and is
+    // randomized by up to half to spread out retrying clients.
+    RetryDelay    time.Duration
+    MaxRetryDelay time.Duration
+    BackoffFactor float64
+}
+
+// NewClient creates a new API client
+func NewClient(baseURL string, opts ...ClientOption) *Client {
+    c := &Client{
+        baseURL: baseURL,
+        httpClient: &http.Client{
+            Timeout: time.Second * time.Duration(30),
+        },
+        retryConfig: &RetryConfig{
+            MaxRetries:    3,
+            RetryDelay:    time.Second * 2,
+            MaxRetryDelay: time.Second * 30,
+            BackoffFactor: 2.0,
+        },
+        rateLimits: newRateLimits(),
+        userAgent: DefaultUserAgent,
+    }
+
+    for _, opt := range opts {
+        opt(c)
+    }
+
+    // Built-in middleware runs outermost, so user middleware sees the
+    // headers it sets
+    middleware := append([]Middleware{UserAgent(c.userAgent), RequestID("")}, c.middleware...)
+    c.doer = chain(c.httpClient, middleware)
+
+    return c
+}
+
+// WithHTTPClient sets a custom HTTP client
+func WithHTTPClient(client *http.Client) ClientOption {
+    return func(c *Client) {
+        c.httpClient = client
+    }
+}
+// WithRetryConfig sets the retry configuration
+func WithRetryConfig(config *RetryConfig) ClientOption {
+    return func(c *Client) {
+        c.retryConfig = config
+    }
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader, contentType string) (*http.Request, error) {
+    // The query is kept out of JoinPath, which would escape it
+    path, rawQuery, _ := strings.Cut(path, "?")
+    u, err := url.JoinPath(c.baseURL, path)
+    if err != nil {
+        return nil, fmt.Errorf("failed to join URL: %w", err)
+    }
+    if rawQuery != "" {
+        u += "?" + rawQuery
+    }
+
+    req, err := http.NewRequestWithContext(ctx, method, u, body)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    if body != nil {
+        req.Header.Set("Content-Type", contentType)
+    }
+    req.Header.Set("Accept", "application/json")
+
+    return req, nil
+}
+
+// BeforeSender is implemented by request types and models that prepare
+// themselves before they are sent. Implement it in a file named *_custom.go,
+// which the generator never touches.
+type BeforeSender interface {
+    BeforeSend() error
+}
+
+// AfterDecoder is implemented by response types and models that complete
+// themselves once they are decoded. Implement it in a file named
+// *_custom.go, which the generator never touches.
+type AfterDecoder interface {
+    AfterDecode() error
+}
+
+// beforeSend calls the BeforeSend hook of v, if it has one
+func beforeSend(v interface{}) error {
+    if h, ok := v.(BeforeSender); ok {
+        if err := h.BeforeSend(); err != nil {
+            return fmt.Errorf("before send: %w", err)
+        }
+    }
+    return nil
+}
+
+// afterDecode calls the AfterDecode hook of v, if it has one
+func afterDecode(v interface{}) error {
+    if h, ok := v.(AfterDecoder); ok {
+        if err := h.AfterDecode(); err != nil {
+            return fmt.Errorf("after decode: %w", err)
+        }
+    }
+    return nil
+}
+
+// jsonBody encodes v as a JSON request body, calling its BeforeSend hook
+// first
+func jsonBody(v interface{}, contentType string) (io.Reader, string, error) {
+    if err := beforeSend(v); err != nil {
+        return nil, "", err
+    }
+    data, err := json.Marshal(v)
+    if err != nil {
+        return nil, "", fmt.Errorf("failed to marshal request body: %w", err)
+    }
+    return bytes.NewReader(data), contentType, nil
+}
+
+// addFormValue adds v to a form-urlencoded or multipart body. Strings,
+// numbers and booleans are sent as text, times in RFC 3339 and anything else
+// as JSON, unless it is encoded as a plain JSON string such as an enum.
+func addFormValue(form url.Values, name string, v interface{}) error {
+    switch v := v.(type) {
+    case nil:
+        return nil
+    case string:
+        form.Add(name, v)
+        return nil
+    case bool, int, int32, int64, float32, float64:
+        form.Add(name, fmt.Sprint(v))
+        return nil
+    case time.Time:
+        form.Add(name, v.Format(time.RFC3339))
+        return nil
+    }
+
+    data, err := json.Marshal(v)
+    if err != nil {
+        return fmt.Errorf("failed to encode form field %s: %w", name, err)
+    }
+    var s string
+    if err := json.Unmarshal(data, &s); err == nil {
+        form.Add(name, s)
+        return nil
+    }
+    form.Add(name, string(data))
+    return nil
+}
+
+// formFile is a file part of a multipart body
+type formFile struct {
+    field   string
+    content io.Reader
+}
+
+// multipartBody encodes fields and files as multipart/form-data. Files are
+// named after the base name of readers that have one, such as *os.File, and
+// after their field otherwise.
+func multipartBody(fields url.Values, files []formFile) (io.Reader, string, error) {
+    var buf bytes.Buffer
+    w := multipart.NewWriter(&buf)
+
+    names := make([]string, 0, len(fields))
+    for name := range fields {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+
+    for _, name := range names {
+        for _, value := range fields[name] {
+            if err := w.WriteField(name, value); err != nil {
+                return nil, "", fmt.Errorf("failed to write form field %s: %w", name, err)
+            }
+        }
+    }
+
+    for _, f := range files {
+        filename := f.field
+        if named, ok := f.content.(interface{ Name() string }); ok {
+            filename = filepath.Base(named.Name())
+        }
+
+        part, err := w.CreateFormFile(f.field, filename)
+        if err != nil {
+            return nil, "", fmt.Errorf("failed to create file part %s: %w", f.field, err)
+        }
+        if _, err := io.Copy(part, f.content); err != nil {
+            return nil, "", fmt.Errorf("failed to write file part %s: %w", f.field, err)
+        }
+    }
+
+    if err := w.Close(); err != nil {
+        return nil, "", fmt.Errorf("failed to finish multipart body: %w", err)
+    }
+    return &buf, w.FormDataContentType(), nil
+}
+
+// do sends req and returns the response whatever its status code. The
+// caller must close the response body.
+// Requests of idempotent operations are retried according to the client's
+// RetryConfig; the last response is returned once retries are exhausted.
+func (c *Client) do(req *http.Request, op operation) (*http.Response, error) {
+    for retries := 0; ; retries++ {
+        if err := c.waitRateLimits(req.Context(), op); err != nil {
+            return nil, err
+        }
+        resp, err := c.doer.Do(req)
+
+        delay, retry := c.retryDelay(req, resp, err, op.idempotent, retries)
+        if !retry {
+            if err != nil {
+                if retries > 0 {
+                    return nil, fmt.Errorf("request failed after %d retries: %w", retries, err)
+                }
+                return nil, fmt.Errorf("request failed: %w", err)
+            }
+            return resp, nil
+        }
+
+        if resp != nil {
+            // Drain the body so that the connection can be reused
+            _, _ = io.Copy(io.Discard, resp.Body)
+            resp.Body.Close()
+        }
+
+        timer := time.NewTimer(delay)
+        select {
+        case <-req.Context().Done():
+            timer.Stop()
+            return nil, req.Context().Err()
+        case <-timer.C:
+        }
+
+        if req.GetBody != nil {
+            body, err := req.GetBody()
+            if err != nil {
+                return nil, fmt.Errorf("failed to rewind request body: %w", err)
+            }
+            req.Body = body
+        }
+    }
+}
+
+// decodeJSON decodes the JSON body of resp into v and calls its AfterDecode
+// hook. An empty body leaves v unchanged.
+func decodeJSON(resp *http.Response, v interface{}) error {
+    if err := json.NewDecoder(resp.Body).Decode(v); err != nil && err != io.EOF {
+        return fmt.Errorf("failed to decode response: %w", err)
+    }
+    return afterDecode(v)
+}
+
+// retryDelay reports whether a request that got resp or failed with err
+// should be sent again after the given number of retries, and how long to
+// wait before doing so
+func (c *Client) retryDelay(req *http.Request, resp *http.Response, err error, idempotent bool, retries int) (time.Duration, bool) {
+    cfg := c.retryConfig
+    if cfg == nil || retries >= cfg.MaxRetries || !idempotent {
+        return 0, false
+    }
+
+    // Bodies that cannot be rewound have been consumed by the first attempt
+    if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+        return 0, false
+    }
+
+    if err != nil {
+        // Network errors are retried, but not cancellation by the caller
+        return c.backoff(retries), req.Context().Err() == nil
+    }
+
+    if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+        return 0, false
+    }
+
+    // The server knows best when to come back, but a wait longer than
+    // MaxRetryDelay is left to the caller
+    if after, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+        return after, cfg.MaxRetryDelay <= 0 || after <= cfg.MaxRetryDelay
+    }
+    return c.backoff(retries), true
+}
+
+// backoff returns the jittered exponential delay before retry number
+// retries+1
+func (c *Client) backoff(retries int) time.Duration {
+    cfg := c.retryConfig
+    factor := math.Max(cfg.BackoffFactor, 1)
+    delay := float64(cfg.RetryDelay) * math.Pow(factor, float64(retries))
+    if cfg.MaxRetryDelay > 0 {
+        delay = math.Min(delay, float64(cfg.MaxRetryDelay))
+    }
+
+    half := int64(delay / 2)
+    return time.Duration(half + rand.Int63n(half+1))
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an
+// HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+    if value == "" {
+        return 0, false
+    }
+    if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+        return time.Duration(seconds) * time.Second, true
+    }
+    if t, err := http.ParseTime(value); err == nil {
+        if wait := time.Until(t); wait > 0 {
+            return wait, true
+        }
+        return 0, true
+    }
+    return 0, false
+}
+
+// newAPIError reads the body of an error response. If the operation
+// documents an error model for the status, model points to a value of that
+// type and is exposed as APIError.Model once decoded.
+func newAPIError(resp *http.Response, model interface{}) error {
+    apiErr := &APIError{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+
+    body, err := io.ReadAll(resp.Body)
+    if err != nil {
+        apiErr.Message = fmt.Sprintf("failed to read error response: %v", err)
+        return apiErr
+    }
+    apiErr.Body = body
+
+    if model != nil && json.Unmarshal(body, model) == nil {
+        apiErr.Model = model
+    }
+
+    // Most APIs report a code and message, whatever their error model
+    var errResp struct {
+        Code    interface{} `json:"code"`
+        Message string      `json:"message"`
+    }
+    if json.Unmarshal(body, &errResp) == nil {
+        if errResp.Code != nil {
+            apiErr.Code = fmt.Sprint(errResp.Code)
+        }
+        apiErr.Message = errResp.Message
+    }
+    if apiErr.Message == "" {
+        apiErr.Message = http.StatusText(resp.StatusCode)
+    }
+
+    return apiErr
+}
+
+// APIError represents an API error response
+type APIError struct {
+    StatusCode int
+    Header     http.Header
+    Code       string
+    Message    string
+    // Body is the raw response body
+    Body []byte
+    // Model is a pointer to the decoded error model documented for the
+    // status code, or nil if there is none or the body did not match it
+    Model interface{}
+}
+
+func (e *APIError) Error() string {
+    if e.Code != "" {
+        return fmt.Sprintf("HTTP %d: %s - %s", e.StatusCode, e.Code, e.Message)
+    }
+    return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
+}
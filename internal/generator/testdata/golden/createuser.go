@@ -0,0 +1,96 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "io"
+    "net/http"
+    "petstore-sdk/models"
+)
+// Createuser This can only be done by the logged in user.
+
+// CreateuserRequest contains the request body for Createuser,
+// sent as application/json
+type CreateuserRequest struct {
+    // Created user object
+    Body models.User
+}
+
+// encode returns the encoded request body and its content type
+func (r *CreateuserRequest) encode() (io.Reader, string, error) {
+    return jsonBody(&r.Body, "application/json")
+}
+
+// CreateuserResponse is the response of Createuser. At most one
+// of the typed bodies is set, depending on the status code.
+type CreateuserResponse struct {
+    StatusCode int
+    Header     http.Header
+    // JSONDefault is the body of the default response: successful operation
+    JSONDefault *models.User
+}
+
+// Createuser This can only be done by the logged in user.
+func (c *Client) Createuser(
+    ctx context.Context,
+    request *CreateuserRequest,
+) (*CreateuserResponse, error) {
+
+    path := "/user"
+
+    // Encode request body
+    var body io.Reader
+    var contentType string
+    if request != nil {
+        if err := beforeSend(request); err != nil {
+            return nil, err
+        }
+        var err error
+        body, contentType, err = request.encode()
+        if err != nil {
+            return nil, fmt.Errorf("failed to encode request body: %w", err)
+        }
+    }
+
+    // Create request
+    req, err := c.newRequest(ctx, "POST", path, body, contentType)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    // Send request
+    op := operation{
+        name:       "Createuser",
+        tags:       []string{"user"},
+        idempotent: false,
+    }
+    resp, err := c.do(req, op)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    // Decode the body documented for the status code. Error statuses are
+    // returned as an *APIError carrying the decoded error model.
+    response := &CreateuserResponse{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+    switch {
+    default:
+        if resp.StatusCode >= 400 {
+            var model models.User
+            return nil, newAPIError(resp, &model)
+        }
+        var v models.User
+        if err := decodeJSON(resp, &v); err != nil {
+            return nil, err
+        }
+        response.JSONDefault = &v
+    }
+
+    if err := afterDecode(response); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
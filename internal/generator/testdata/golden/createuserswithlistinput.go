@@ -0,0 +1,95 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "io"
+    "net/http"
+    "petstore-sdk/models"
+)
+// Createuserswithlistinput Creates list of users with given input array
+
+// CreateuserswithlistinputRequest contains the request body for Createuserswithlistinput,
+// sent as application/json
+type CreateuserswithlistinputRequest struct {
+    Body []models.User
+}
+
+// encode returns the encoded request body and its content type
+func (r *CreateuserswithlistinputRequest) encode() (io.Reader, string, error) {
+    return jsonBody(&r.Body, "application/json")
+}
+
+// CreateuserswithlistinputResponse is the response of Createuserswithlistinput. At most one
+// of the typed bodies is set, depending on the status code.
+type CreateuserswithlistinputResponse struct {
+    StatusCode int
+    Header     http.Header
+    // JSON200 is the body of a 200 response: Successful operation
+    JSON200 *models.User
+}
+
+// Createuserswithlistinput Creates list of users with given input array
+func (c *Client) Createuserswithlistinput(
+    ctx context.Context,
+    request *CreateuserswithlistinputRequest,
+) (*CreateuserswithlistinputResponse, error) {
+
+    path := "/user/createWithList"
+
+    // Encode request body
+    var body io.Reader
+    var contentType string
+    if request != nil {
+        if err := beforeSend(request); err != nil {
+            return nil, err
+        }
+        var err error
+        body, contentType, err = request.encode()
+        if err != nil {
+            return nil, fmt.Errorf("failed to encode request body: %w", err)
+        }
+    }
+
+    // Create request
+    req, err := c.newRequest(ctx, "POST", path, body, contentType)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    // Send request
+    op := operation{
+        name:       "Createuserswithlistinput",
+        tags:       []string{"user"},
+        idempotent: false,
+    }
+    resp, err := c.do(req, op)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    // Decode the body documented for the status code. Error statuses are
+    // returned as an *APIError carrying the decoded error model.
+    response := &CreateuserswithlistinputResponse{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+    switch {
+    case resp.StatusCode == 200:
+        var v models.User
+        if err := decodeJSON(resp, &v); err != nil {
+            return nil, err
+        }
+        response.JSON200 = &v
+    default:
+        if resp.StatusCode >= 400 {
+            return nil, newAPIError(resp, nil)
+        }
+    }
+
+    if err := afterDecode(response); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
@@ -0,0 +1,85 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+)
+// Deleteorder For valid response try integer IDs with value < 1000. Anything above 1000 or nonintegers will generate API errors
+
+// DeleteorderParams contains the parameters for Deleteorder
+type DeleteorderParams struct {
+    // ID of the order that needs to be deleted
+    OrderId int64 `json:"orderId" validate:"required"`
+}
+
+// DeleteorderResponse is the response of Deleteorder. At most one
+// of the typed bodies is set, depending on the status code.
+type DeleteorderResponse struct {
+    StatusCode int
+    Header     http.Header
+}
+
+// Deleteorder For valid response try integer IDs with value < 1000. Anything above 1000 or nonintegers will generate API errors
+func (c *Client) Deleteorder(
+    ctx context.Context,
+    params *DeleteorderParams,
+) (*DeleteorderResponse, error) {
+    if params == nil {
+        return nil, fmt.Errorf("params cannot be nil")
+    }
+    if err := params.validate(); err != nil {
+        return nil, err
+    }
+
+    // Build path with path parameters
+    path, err := buildPath("/store/order/{orderId}",
+        pathParam{name: "orderId", value: params.OrderId, style: "simple", explode: false},
+    )
+    if err != nil {
+        return nil, err
+    }
+
+    // Create request
+    req, err := c.newRequest(ctx, "DELETE", path, nil, "")
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    // Send request
+    op := operation{
+        name:       "Deleteorder",
+        tags:       []string{"store"},
+        idempotent: true,
+    }
+    resp, err := c.do(req, op)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    // Decode the body documented for the status code. Error statuses are
+    // returned as an *APIError carrying the decoded error model.
+    response := &DeleteorderResponse{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+    switch {
+    case resp.StatusCode == 400:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode == 404:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode >= 400:
+        return nil, newAPIError(resp, nil)
+    }
+
+    if err := afterDecode(response); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
+
+// validate checks that the required parameters are set
+func (p *DeleteorderParams) validate() error {
+    return nil
+}
@@ -0,0 +1,73 @@
+package myapi
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestDeleteorder(t *testing.T) {
+    tests := []struct {
+        name       string
+        params     *DeleteorderParams
+        status     int // status the server replies with
+        errMessage string
+    }{
+        {
+            name:   "successful request",
+            params: &DeleteorderParams{
+                OrderId: 1,
+            },
+            status: http.StatusOK,
+        },
+        {
+            name:   "server error",
+            params: &DeleteorderParams{
+                OrderId: 1,
+            },
+            status:     http.StatusInternalServerError,
+            errMessage: "internal server error",
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+                assert.Equal(t, "DELETE", r.Method)
+
+                if tt.status >= 400 {
+                    w.Header().Set("Content-Type", "application/json")
+                    w.WriteHeader(tt.status)
+                    fmt.Fprint(w, `{"code": "SERVER_ERROR", "message": "internal server error"}`)
+                    return
+                }
+                w.WriteHeader(tt.status)
+            }))
+            defer server.Close()
+
+            client := NewClient(server.URL, WithRetryConfig(&RetryConfig{}))
+            resp, err := client.Deleteorder(context.Background(), tt.params)
+
+            if tt.errMessage != "" {
+                require.Error(t, err)
+                assert.Contains(t, err.Error(), tt.errMessage)
+                if tt.status >= 400 {
+                    var apiErr *APIError
+                    require.True(t, errors.As(err, &apiErr))
+                    assert.Equal(t, tt.status, apiErr.StatusCode)
+                }
+                return
+            }
+
+            require.NoError(t, err)
+            require.NotNil(t, resp)
+            assert.Equal(t, tt.status, resp.StatusCode)
+        })
+    }
+}
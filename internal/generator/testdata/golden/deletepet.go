@@ -0,0 +1,92 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+)
+// Deletepet delete a pet
+
+// DeletepetParams contains the parameters for Deletepet
+type DeletepetParams struct {
+    ApiKey *string `json:"api_key,omitempty"`
+    // Pet id to delete
+    PetId int64 `json:"petId" validate:"required"`
+}
+
+// DeletepetResponse is the response of Deletepet. At most one
+// of the typed bodies is set, depending on the status code.
+type DeletepetResponse struct {
+    StatusCode int
+    Header     http.Header
+}
+
+// Deletepet delete a pet
+func (c *Client) Deletepet(
+    ctx context.Context,
+    params *DeletepetParams,
+) (*DeletepetResponse, error) {
+    if params == nil {
+        return nil, fmt.Errorf("params cannot be nil")
+    }
+    if err := params.validate(); err != nil {
+        return nil, err
+    }
+
+    // Build path with path parameters
+    path, err := buildPath("/pet/{petId}",
+        pathParam{name: "petId", value: params.PetId, style: "simple", explode: false},
+    )
+    if err != nil {
+        return nil, err
+    }
+
+    // Create request
+    req, err := c.newRequest(ctx, "DELETE", path, nil, "")
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+    if v, ok := headerParam(params.ApiKey, false); ok {
+        req.Header.Set("api_key", v)
+    }
+
+    // Apply the credentials of the first satisfiable security requirement
+    if err := c.authenticate(req, [][]securityRequirement{{{"petstore_auth", []string{"write:pets", "read:pets"}}}}); err != nil {
+        return nil, err
+    }
+
+    // Send request
+    op := operation{
+        name:       "Deletepet",
+        tags:       []string{"pet"},
+        idempotent: true,
+    }
+    resp, err := c.do(req, op)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    // Decode the body documented for the status code. Error statuses are
+    // returned as an *APIError carrying the decoded error model.
+    response := &DeletepetResponse{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+    switch {
+    case resp.StatusCode == 400:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode >= 400:
+        return nil, newAPIError(resp, nil)
+    }
+
+    if err := afterDecode(response); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
+
+// validate checks that the required parameters are set
+func (p *DeletepetParams) validate() error {
+    return nil
+}
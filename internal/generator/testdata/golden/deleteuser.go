@@ -0,0 +1,85 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+)
+// Deleteuser This can only be done by the logged in user.
+
+// DeleteuserParams contains the parameters for Deleteuser
+type DeleteuserParams struct {
+    // The name that needs to be deleted
+    Username string `json:"username" validate:"required"`
+}
+
+// DeleteuserResponse is the response of Deleteuser. At most one
+// of the typed bodies is set, depending on the status code.
+type DeleteuserResponse struct {
+    StatusCode int
+    Header     http.Header
+}
+
+// Deleteuser This can only be done by the logged in user.
+func (c *Client) Deleteuser(
+    ctx context.Context,
+    params *DeleteuserParams,
+) (*DeleteuserResponse, error) {
+    if params == nil {
+        return nil, fmt.Errorf("params cannot be nil")
+    }
+    if err := params.validate(); err != nil {
+        return nil, err
+    }
+
+    // Build path with path parameters
+    path, err := buildPath("/user/{username}",
+        pathParam{name: "username", value: params.Username, style: "simple", explode: false},
+    )
+    if err != nil {
+        return nil, err
+    }
+
+    // Create request
+    req, err := c.newRequest(ctx, "DELETE", path, nil, "")
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    // Send request
+    op := operation{
+        name:       "Deleteuser",
+        tags:       []string{"user"},
+        idempotent: true,
+    }
+    resp, err := c.do(req, op)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    // Decode the body documented for the status code. Error statuses are
+    // returned as an *APIError carrying the decoded error model.
+    response := &DeleteuserResponse{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+    switch {
+    case resp.StatusCode == 400:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode == 404:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode >= 400:
+        return nil, newAPIError(resp, nil)
+    }
+
+    if err := afterDecode(response); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
+
+// validate checks that the required parameters are set
+func (p *DeleteuserParams) validate() error {
+    return nil
+}
@@ -0,0 +1,98 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+    "petstore-sdk/models"
+)
+// Findpetsbystatus Multiple status values can be provided with comma separated strings
+
+// FindpetsbystatusParams contains the parameters for Findpetsbystatus
+type FindpetsbystatusParams struct {
+    // Status values that need to be considered for filter
+    Status *string `json:"status,omitempty" validate:"oneof=available pending sold"`
+}
+
+// FindpetsbystatusResponse is the response of Findpetsbystatus. At most one
+// of the typed bodies is set, depending on the status code.
+type FindpetsbystatusResponse struct {
+    StatusCode int
+    Header     http.Header
+    // JSON200 is the body of a 200 response: successful operation
+    JSON200 []models.Pet
+}
+
+// Findpetsbystatus Multiple status values can be provided with comma separated strings
+func (c *Client) Findpetsbystatus(
+    ctx context.Context,
+    params *FindpetsbystatusParams,
+) (*FindpetsbystatusResponse, error) {
+    if params == nil {
+        return nil, fmt.Errorf("params cannot be nil")
+    }
+    if err := params.validate(); err != nil {
+        return nil, err
+    }
+
+    path := "/pet/findByStatus"
+
+    // Add query parameters
+    var query queryParams
+    query.add("status", params.Status, "form", true, false)
+    if len(query) > 0 {
+        path += "?" + query.encode()
+    }
+
+    // Create request
+    req, err := c.newRequest(ctx, "GET", path, nil, "")
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    // Apply the credentials of the first satisfiable security requirement
+    if err := c.authenticate(req, [][]securityRequirement{{{"petstore_auth", []string{"write:pets", "read:pets"}}}}); err != nil {
+        return nil, err
+    }
+
+    // Send request
+    op := operation{
+        name:       "Findpetsbystatus",
+        tags:       []string{"pet"},
+        idempotent: true,
+    }
+    resp, err := c.do(req, op)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    // Decode the body documented for the status code. Error statuses are
+    // returned as an *APIError carrying the decoded error model.
+    response := &FindpetsbystatusResponse{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+    switch {
+    case resp.StatusCode == 200:
+        var v []models.Pet
+        if err := decodeJSON(resp, &v); err != nil {
+            return nil, err
+        }
+        response.JSON200 = v
+    case resp.StatusCode == 400:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode >= 400:
+        return nil, newAPIError(resp, nil)
+    }
+
+    if err := afterDecode(response); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
+
+// validate checks that the required parameters are set
+func (p *FindpetsbystatusParams) validate() error {
+    return nil
+}
@@ -0,0 +1,98 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+    "petstore-sdk/models"
+)
+// Findpetsbytags Multiple tags can be provided with comma separated strings. Use tag1, tag2, tag3 for testing.
+
+// FindpetsbytagsParams contains the parameters for Findpetsbytags
+type FindpetsbytagsParams struct {
+    // Tags to filter by
+    Tags []string `json:"tags,omitempty"`
+}
+
+// FindpetsbytagsResponse is the response of Findpetsbytags. At most one
+// of the typed bodies is set, depending on the status code.
+type FindpetsbytagsResponse struct {
+    StatusCode int
+    Header     http.Header
+    // JSON200 is the body of a 200 response: successful operation
+    JSON200 []models.Pet
+}
+
+// Findpetsbytags Multiple tags can be provided with comma separated strings. Use tag1, tag2, tag3 for testing.
+func (c *Client) Findpetsbytags(
+    ctx context.Context,
+    params *FindpetsbytagsParams,
+) (*FindpetsbytagsResponse, error) {
+    if params == nil {
+        return nil, fmt.Errorf("params cannot be nil")
+    }
+    if err := params.validate(); err != nil {
+        return nil, err
+    }
+
+    path := "/pet/findByTags"
+
+    // Add query parameters
+    var query queryParams
+    query.add("tags", params.Tags, "form", true, false)
+    if len(query) > 0 {
+        path += "?" + query.encode()
+    }
+
+    // Create request
+    req, err := c.newRequest(ctx, "GET", path, nil, "")
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    // Apply the credentials of the first satisfiable security requirement
+    if err := c.authenticate(req, [][]securityRequirement{{{"petstore_auth", []string{"write:pets", "read:pets"}}}}); err != nil {
+        return nil, err
+    }
+
+    // Send request
+    op := operation{
+        name:       "Findpetsbytags",
+        tags:       []string{"pet"},
+        idempotent: true,
+    }
+    resp, err := c.do(req, op)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    // Decode the body documented for the status code. Error statuses are
+    // returned as an *APIError carrying the decoded error model.
+    response := &FindpetsbytagsResponse{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+    switch {
+    case resp.StatusCode == 200:
+        var v []models.Pet
+        if err := decodeJSON(resp, &v); err != nil {
+            return nil, err
+        }
+        response.JSON200 = v
+    case resp.StatusCode == 400:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode >= 400:
+        return nil, newAPIError(resp, nil)
+    }
+
+    if err := afterDecode(response); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
+
+// validate checks that the required parameters are set
+func (p *FindpetsbytagsParams) validate() error {
+    return nil
+}
@@ -0,0 +1,70 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+)
+// Getinventory Returns a map of status codes to quantities
+
+// GetinventoryResponse is the response of Getinventory. At most one
+// of the typed bodies is set, depending on the status code.
+type GetinventoryResponse struct {
+    StatusCode int
+    Header     http.Header
+    // JSON200 is the body of a 200 response: successful operation
+    JSON200 map[string]int32
+}
+
+// Getinventory Returns a map of status codes to quantities
+func (c *Client) Getinventory(
+    ctx context.Context,
+) (*GetinventoryResponse, error) {
+
+    path := "/store/inventory"
+
+    // Create request
+    req, err := c.newRequest(ctx, "GET", path, nil, "")
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    // Apply the credentials of the first satisfiable security requirement
+    if err := c.authenticate(req, [][]securityRequirement{{{"api_key", nil}}}); err != nil {
+        return nil, err
+    }
+
+    // Send request
+    op := operation{
+        name:       "Getinventory",
+        tags:       []string{"store"},
+        idempotent: true,
+    }
+    resp, err := c.do(req, op)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    // Decode the body documented for the status code. Error statuses are
+    // returned as an *APIError carrying the decoded error model.
+    response := &GetinventoryResponse{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+    switch {
+    case resp.StatusCode == 200:
+        var v map[string]int32
+        if err := decodeJSON(resp, &v); err != nil {
+            return nil, err
+        }
+        response.JSON200 = v
+    case resp.StatusCode >= 400:
+        return nil, newAPIError(resp, nil)
+    }
+
+    if err := afterDecode(response); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
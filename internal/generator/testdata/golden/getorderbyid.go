@@ -0,0 +1,94 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+    "petstore-sdk/models"
+)
+// Getorderbyid For valid response try integer IDs with value <= 5 or > 10. Other values will generate exceptions.
+
+// GetorderbyidParams contains the parameters for Getorderbyid
+type GetorderbyidParams struct {
+    // ID of order that needs to be fetched
+    OrderId int64 `json:"orderId" validate:"required"`
+}
+
+// GetorderbyidResponse is the response of Getorderbyid. At most one
+// of the typed bodies is set, depending on the status code.
+type GetorderbyidResponse struct {
+    StatusCode int
+    Header     http.Header
+    // JSON200 is the body of a 200 response: successful operation
+    JSON200 *models.Order
+}
+
+// Getorderbyid For valid response try integer IDs with value <= 5 or > 10. Other values will generate exceptions.
+func (c *Client) Getorderbyid(
+    ctx context.Context,
+    params *GetorderbyidParams,
+) (*GetorderbyidResponse, error) {
+    if params == nil {
+        return nil, fmt.Errorf("params cannot be nil")
+    }
+    if err := params.validate(); err != nil {
+        return nil, err
+    }
+
+    // Build path with path parameters
+    path, err := buildPath("/store/order/{orderId}",
+        pathParam{name: "orderId", value: params.OrderId, style: "simple", explode: false},
+    )
+    if err != nil {
+        return nil, err
+    }
+
+    // Create request
+    req, err := c.newRequest(ctx, "GET", path, nil, "")
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    // Send request
+    op := operation{
+        name:       "Getorderbyid",
+        tags:       []string{"store"},
+        idempotent: true,
+    }
+    resp, err := c.do(req, op)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    // Decode the body documented for the status code. Error statuses are
+    // returned as an *APIError carrying the decoded error model.
+    response := &GetorderbyidResponse{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+    switch {
+    case resp.StatusCode == 200:
+        var v models.Order
+        if err := decodeJSON(resp, &v); err != nil {
+            return nil, err
+        }
+        response.JSON200 = &v
+    case resp.StatusCode == 400:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode == 404:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode >= 400:
+        return nil, newAPIError(resp, nil)
+    }
+
+    if err := afterDecode(response); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
+
+// validate checks that the required parameters are set
+func (p *GetorderbyidParams) validate() error {
+    return nil
+}
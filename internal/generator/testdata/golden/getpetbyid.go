@@ -0,0 +1,99 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+    "petstore-sdk/models"
+)
+// Getpetbyid Returns a single pet
+
+// GetpetbyidParams contains the parameters for Getpetbyid
+type GetpetbyidParams struct {
+    // ID of pet to return
+    PetId int64 `json:"petId" validate:"required"`
+}
+
+// GetpetbyidResponse is the response of Getpetbyid. At most one
+// of the typed bodies is set, depending on the status code.
+type GetpetbyidResponse struct {
+    StatusCode int
+    Header     http.Header
+    // JSON200 is the body of a 200 response: successful operation
+    JSON200 *models.Pet
+}
+
+// Getpetbyid Returns a single pet
+func (c *Client) Getpetbyid(
+    ctx context.Context,
+    params *GetpetbyidParams,
+) (*GetpetbyidResponse, error) {
+    if params == nil {
+        return nil, fmt.Errorf("params cannot be nil")
+    }
+    if err := params.validate(); err != nil {
+        return nil, err
+    }
+
+    // Build path with path parameters
+    path, err := buildPath("/pet/{petId}",
+        pathParam{name: "petId", value: params.PetId, style: "simple", explode: false},
+    )
+    if err != nil {
+        return nil, err
+    }
+
+    // Create request
+    req, err := c.newRequest(ctx, "GET", path, nil, "")
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    // Apply the credentials of the first satisfiable security requirement
+    if err := c.authenticate(req, [][]securityRequirement{{{"api_key", nil}}, {{"petstore_auth", []string{"write:pets", "read:pets"}}}}); err != nil {
+        return nil, err
+    }
+
+    // Send request
+    op := operation{
+        name:       "Getpetbyid",
+        tags:       []string{"pet"},
+        idempotent: true,
+    }
+    resp, err := c.do(req, op)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    // Decode the body documented for the status code. Error statuses are
+    // returned as an *APIError carrying the decoded error model.
+    response := &GetpetbyidResponse{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+    switch {
+    case resp.StatusCode == 200:
+        var v models.Pet
+        if err := decodeJSON(resp, &v); err != nil {
+            return nil, err
+        }
+        response.JSON200 = &v
+    case resp.StatusCode == 400:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode == 404:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode >= 400:
+        return nil, newAPIError(resp, nil)
+    }
+
+    if err := afterDecode(response); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
+
+// validate checks that the required parameters are set
+func (p *GetpetbyidParams) validate() error {
+    return nil
+}
@@ -0,0 +1,93 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+    "petstore-sdk/models"
+)
+
+// GetuserbynameParams contains the parameters for Getuserbyname
+type GetuserbynameParams struct {
+    // The name that needs to be fetched. Use user1 for testing. 
+    Username string `json:"username" validate:"required"`
+}
+
+// GetuserbynameResponse is the response of Getuserbyname. At most one
+// of the typed bodies is set, depending on the status code.
+type GetuserbynameResponse struct {
+    StatusCode int
+    Header     http.Header
+    // JSON200 is the body of a 200 response: successful operation
+    JSON200 *models.User
+}
+
+// Getuserbyname 
+func (c *Client) Getuserbyname(
+    ctx context.Context,
+    params *GetuserbynameParams,
+) (*GetuserbynameResponse, error) {
+    if params == nil {
+        return nil, fmt.Errorf("params cannot be nil")
+    }
+    if err := params.validate(); err != nil {
+        return nil, err
+    }
+
+    // Build path with path parameters
+    path, err := buildPath("/user/{username}",
+        pathParam{name: "username", value: params.Username, style: "simple", explode: false},
+    )
+    if err != nil {
+        return nil, err
+    }
+
+    // Create request
+    req, err := c.newRequest(ctx, "GET", path, nil, "")
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    // Send request
+    op := operation{
+        name:       "Getuserbyname",
+        tags:       []string{"user"},
+        idempotent: true,
+    }
+    resp, err := c.do(req, op)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    // Decode the body documented for the status code. Error statuses are
+    // returned as an *APIError carrying the decoded error model.
+    response := &GetuserbynameResponse{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+    switch {
+    case resp.StatusCode == 200:
+        var v models.User
+        if err := decodeJSON(resp, &v); err != nil {
+            return nil, err
+        }
+        response.JSON200 = &v
+    case resp.StatusCode == 400:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode == 404:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode >= 400:
+        return nil, newAPIError(resp, nil)
+    }
+
+    if err := afterDecode(response); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
+
+// validate checks that the required parameters are set
+func (p *GetuserbynameParams) validate() error {
+    return nil
+}
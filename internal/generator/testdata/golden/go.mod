@@ -0,0 +1,15 @@
+module petstore-sdk
+
+// Iterators over paginated lists need Go 1.23
+go 1.23
+
+require (
+	github.com/stretchr/testify v1.9.0
+	golang.org/x/time v0.5.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
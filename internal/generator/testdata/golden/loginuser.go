@@ -0,0 +1,94 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+)
+
+// LoginuserParams contains the parameters for Loginuser
+type LoginuserParams struct {
+    // The user name for login
+    Username *string `json:"username,omitempty"`
+    // The password for login in clear text
+    Password *string `json:"password,omitempty"`
+}
+
+// LoginuserResponse is the response of Loginuser. At most one
+// of the typed bodies is set, depending on the status code.
+type LoginuserResponse struct {
+    StatusCode int
+    Header     http.Header
+    // JSON200 is the body of a 200 response: successful operation
+    JSON200 *string
+}
+
+// Loginuser 
+func (c *Client) Loginuser(
+    ctx context.Context,
+    params *LoginuserParams,
+) (*LoginuserResponse, error) {
+    if params == nil {
+        return nil, fmt.Errorf("params cannot be nil")
+    }
+    if err := params.validate(); err != nil {
+        return nil, err
+    }
+
+    path := "/user/login"
+
+    // Add query parameters
+    var query queryParams
+    query.add("username", params.Username, "form", true, false)
+    query.add("password", params.Password, "form", true, false)
+    if len(query) > 0 {
+        path += "?" + query.encode()
+    }
+
+    // Create request
+    req, err := c.newRequest(ctx, "GET", path, nil, "")
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    // Send request
+    op := operation{
+        name:       "Loginuser",
+        tags:       []string{"user"},
+        idempotent: true,
+    }
+    resp, err := c.do(req, op)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    // Decode the body documented for the status code. Error statuses are
+    // returned as an *APIError carrying the decoded error model.
+    response := &LoginuserResponse{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+    switch {
+    case resp.StatusCode == 200:
+        var v string
+        if err := decodeJSON(resp, &v); err != nil {
+            return nil, err
+        }
+        response.JSON200 = &v
+    case resp.StatusCode == 400:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode >= 400:
+        return nil, newAPIError(resp, nil)
+    }
+
+    if err := afterDecode(response); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
+
+// validate checks that the required parameters are set
+func (p *LoginuserParams) validate() error {
+    return nil
+}
@@ -0,0 +1,58 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+)
+
+// LogoutuserResponse is the response of Logoutuser. At most one
+// of the typed bodies is set, depending on the status code.
+type LogoutuserResponse struct {
+    StatusCode int
+    Header     http.Header
+}
+
+// Logoutuser 
+func (c *Client) Logoutuser(
+    ctx context.Context,
+) (*LogoutuserResponse, error) {
+
+    path := "/user/logout"
+
+    // Create request
+    req, err := c.newRequest(ctx, "GET", path, nil, "")
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    // Send request
+    op := operation{
+        name:       "Logoutuser",
+        tags:       []string{"user"},
+        idempotent: true,
+    }
+    resp, err := c.do(req, op)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    // Decode the body documented for the status code. Error statuses are
+    // returned as an *APIError carrying the decoded error model.
+    response := &LogoutuserResponse{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+    switch {
+    default:
+        if resp.StatusCode >= 400 {
+            return nil, newAPIError(resp, nil)
+        }
+    }
+
+    if err := afterDecode(response); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
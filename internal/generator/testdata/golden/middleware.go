@@ -0,0 +1,121 @@
+package myapi
+
+import (
+    "crypto/rand"
+    "encoding/hex"
+    "net/http"
+    "time"
+)
+
+// DefaultUserAgent is sent as the User-Agent of requests unless changed with
+// WithUserAgent
+const DefaultUserAgent = "MyAPIClient/1.0.11"
+
+// Doer sends HTTP requests. *http.Client is a Doer.
+type Doer interface {
+    Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to the Doer interface
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do implements Doer
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+    return f(req)
+}
+
+// Middleware wraps the Doer that sends requests, e.g. to add headers,
+// tracing or auditing. It runs for every attempt of a request, including
+// retries.
+type Middleware func(next Doer) Doer
+
+// WithMiddleware adds middleware to the client. Middleware added first runs
+// first, after the built-in user agent and request ID middleware.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+    return func(c *Client) {
+        c.middleware = append(c.middleware, middleware...)
+    }
+}
+
+// WithUserAgent replaces DefaultUserAgent as the User-Agent of requests
+func WithUserAgent(userAgent string) ClientOption {
+    return func(c *Client) {
+        c.userAgent = userAgent
+    }
+}
+
+// chain wraps doer in middleware so that the first middleware runs first
+func chain(doer Doer, middleware []Middleware) Doer {
+    for i := len(middleware) - 1; i >= 0; i-- {
+        doer = middleware[i](doer)
+    }
+    return doer
+}
+
+// UserAgent returns middleware setting the User-Agent header of requests
+// that have none
+func UserAgent(userAgent string) Middleware {
+    return func(next Doer) Doer {
+        return DoerFunc(func(req *http.Request) (*http.Response, error) {
+            if userAgent != "" && req.Header.Get("User-Agent") == "" {
+                req.Header.Set("User-Agent", userAgent)
+            }
+            return next.Do(req)
+        })
+    }
+}
+
+// RequestIDHeader is the header RequestID sets by default
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID returns middleware setting header, or RequestIDHeader if empty,
+// to a random ID on requests that have none. Retries of a request keep its
+// ID.
+func RequestID(header string) Middleware {
+    if header == "" {
+        header = RequestIDHeader
+    }
+    return func(next Doer) Doer {
+        return DoerFunc(func(req *http.Request) (*http.Response, error) {
+            if req.Header.Get(header) == "" {
+                req.Header.Set(header, newRequestID())
+            }
+            return next.Do(req)
+        })
+    }
+}
+
+func newRequestID() string {
+    var b [16]byte
+    if _, err := rand.Read(b[:]); err != nil {
+        return ""
+    }
+    return hex.EncodeToString(b[:])
+}
+
+// LogHooks are called by the Logging middleware. Either hook may be nil.
+type LogHooks struct {
+    // OnRequest is called before a request is sent
+    OnRequest func(req *http.Request)
+    // OnResponse is called once a request completed, with its response or
+    // error and how long it took. The response body must not be consumed.
+    OnResponse func(req *http.Request, resp *http.Response, err error, duration time.Duration)
+}
+
+// Logging returns middleware calling hooks around every request, for
+// logging, metrics or auditing
+func Logging(hooks LogHooks) Middleware {
+    return func(next Doer) Doer {
+        return DoerFunc(func(req *http.Request) (*http.Response, error) {
+            if hooks.OnRequest != nil {
+                hooks.OnRequest(req)
+            }
+            start := time.Now()
+            resp, err := next.Do(req)
+            if hooks.OnResponse != nil {
+                hooks.OnResponse(req, resp, err, time.Since(start))
+            }
+            return resp, err
+        })
+    }
+}
@@ -0,0 +1,34 @@
+package models
+
+type Address struct {
+    City *string `json:"city,omitempty"`
+    State *string `json:"state,omitempty"`
+    Street *string `json:"street,omitempty"`
+    Zip *string `json:"zip,omitempty"`
+}
+
+// Validate checks if the Address satisfies all constraints and reports
+// every violation it finds
+func (m *Address) Validate() error {
+    var errs FieldErrors
+    return errs.Err()
+}
+
+// ExampleAddress returns an example instance of Address
+func ExampleAddress() *Address {
+    return &Address{
+        City: Ptr[string]("Palo Alto"),
+        State: Ptr[string]("CA"),
+        Street: Ptr[string]("437 Lytton"),
+        Zip: Ptr[string]("94301"),
+    }
+}
+
+// AddressInterface defines the interface for Address
+type AddressInterface interface {
+    Validate() error
+    // Add any additional interface methods here
+}
+
+// Ensure Address implements AddressInterface
+var _ AddressInterface = (*Address)(nil)
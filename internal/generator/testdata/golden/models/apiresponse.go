@@ -0,0 +1,32 @@
+package models
+
+type ApiResponse struct {
+    Code *int32 `json:"code,omitempty"`
+    Message *string `json:"message,omitempty"`
+    Type *string `json:"type,omitempty"`
+}
+
+// Validate checks if the ApiResponse satisfies all constraints and reports
+// every violation it finds
+func (m *ApiResponse) Validate() error {
+    var errs FieldErrors
+    return errs.Err()
+}
+
+// ExampleApiResponse returns an example instance of ApiResponse
+func ExampleApiResponse() *ApiResponse {
+    return &ApiResponse{
+        Code: Ptr[int32](1),
+        Message: Ptr[string]("example"),
+        Type: Ptr[string]("example"),
+    }
+}
+
+// ApiResponseInterface defines the interface for ApiResponse
+type ApiResponseInterface interface {
+    Validate() error
+    // Add any additional interface methods here
+}
+
+// Ensure ApiResponse implements ApiResponseInterface
+var _ ApiResponseInterface = (*ApiResponse)(nil)
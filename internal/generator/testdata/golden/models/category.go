@@ -0,0 +1,30 @@
+package models
+
+type Category struct {
+    Id *int64 `json:"id,omitempty"`
+    Name *string `json:"name,omitempty"`
+}
+
+// Validate checks if the Category satisfies all constraints and reports
+// every violation it finds
+func (m *Category) Validate() error {
+    var errs FieldErrors
+    return errs.Err()
+}
+
+// ExampleCategory returns an example instance of Category
+func ExampleCategory() *Category {
+    return &Category{
+        Id: Ptr[int64](1),
+        Name: Ptr[string]("Dogs"),
+    }
+}
+
+// CategoryInterface defines the interface for Category
+type CategoryInterface interface {
+    Validate() error
+    // Add any additional interface methods here
+}
+
+// Ensure Category implements CategoryInterface
+var _ CategoryInterface = (*Category)(nil)
@@ -0,0 +1,37 @@
+package models
+
+type Customer struct {
+    Address []Address `json:"address,omitempty"`
+    Id *int64 `json:"id,omitempty"`
+    Username *string `json:"username,omitempty"`
+}
+
+// Validate checks if the Customer satisfies all constraints and reports
+// every violation it finds
+func (m *Customer) Validate() error {
+    var errs FieldErrors
+    for i := range m.Address {
+        if err := m.Address[i].Validate(); err != nil {
+            errs.Merge(indexPath("address", i), err)
+        }
+    }
+    return errs.Err()
+}
+
+// ExampleCustomer returns an example instance of Customer
+func ExampleCustomer() *Customer {
+    return &Customer{
+        Address: nil,
+        Id: Ptr[int64](100000),
+        Username: Ptr[string]("fehguy"),
+    }
+}
+
+// CustomerInterface defines the interface for Customer
+type CustomerInterface interface {
+    Validate() error
+    // Add any additional interface methods here
+}
+
+// Ensure Customer implements CustomerInterface
+var _ CustomerInterface = (*Customer)(nil)
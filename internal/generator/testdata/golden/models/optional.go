@@ -0,0 +1,98 @@
+package models
+
+import (
+    "encoding/json"
+    "errors"
+)
+
+// Ptr returns a pointer to v, for setting optional fields from literals
+func Ptr[T any](v T) *T {
+    return &v
+}
+
+// Nullable is a field that can be absent, explicitly null or hold a value.
+// The zero value is absent and is left out of JSON by omitempty.
+type Nullable[T any] map[bool]T
+
+// NewNullable returns a Nullable holding v
+func NewNullable[T any](v T) Nullable[T] {
+    return Nullable[T]{true: v}
+}
+
+// NewNullNullable returns a Nullable that is explicitly null
+func NewNullNullable[T any]() Nullable[T] {
+    return Nullable[T]{false: *new(T)}
+}
+
+// Get returns the value held by n, or an error if it is null or absent
+func (n Nullable[T]) Get() (T, error) {
+    var empty T
+    if n.IsNull() {
+        return empty, errors.New("value is null")
+    }
+    if !n.IsSpecified() {
+        return empty, errors.New("value is not specified")
+    }
+    return n[true], nil
+}
+
+// Value returns the value held by n, or the zero value of T if it is null
+// or absent
+func (n Nullable[T]) Value() T {
+    return n[true]
+}
+
+// HasValue reports whether n holds a value
+func (n Nullable[T]) HasValue() bool {
+    _, ok := n[true]
+    return ok
+}
+
+// IsNull reports whether n is explicitly null
+func (n Nullable[T]) IsNull() bool {
+    _, ok := n[false]
+    return ok
+}
+
+// IsSpecified reports whether n is either null or holds a value
+func (n Nullable[T]) IsSpecified() bool {
+    return len(n) != 0
+}
+
+// Set makes n hold v
+func (n *Nullable[T]) Set(v T) {
+    *n = Nullable[T]{true: v}
+}
+
+// SetNull makes n explicitly null
+func (n *Nullable[T]) SetNull() {
+    *n = Nullable[T]{false: *new(T)}
+}
+
+// Unset makes n absent
+func (n *Nullable[T]) Unset() {
+    *n = nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+    if !n.HasValue() {
+        return []byte("null"), nil
+    }
+    return json.Marshal(n[true])
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+    if string(data) == "null" {
+        n.SetNull()
+        return nil
+    }
+
+    var v T
+    if err := json.Unmarshal(data, &v); err != nil {
+        return err
+    }
+    n.Set(v)
+    return nil
+}
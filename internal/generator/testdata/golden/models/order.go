@@ -0,0 +1,48 @@
+package models
+
+import (
+    "time"
+)
+
+type Order struct {
+    Complete *bool `json:"complete,omitempty"`
+    Id *int64 `json:"id,omitempty"`
+    PetId *int64 `json:"petId,omitempty"`
+    Quantity *int32 `json:"quantity,omitempty"`
+    ShipDate *time.Time `json:"shipDate,omitempty"`
+    // Order Status
+    Status *OrderStatus `json:"status,omitempty" validate:"oneof=placed approved delivered"`
+}
+
+// Validate checks if the Order satisfies all constraints and reports
+// every violation it finds
+func (m *Order) Validate() error {
+    var errs FieldErrors
+    if m.Status != nil {
+        if err := m.Status.Validate(); err != nil {
+            errs.Merge("status", err)
+        }
+    }
+    return errs.Err()
+}
+
+// ExampleOrder returns an example instance of Order
+func ExampleOrder() *Order {
+    return &Order{
+        Complete: Ptr[bool](true),
+        Id: Ptr[int64](10),
+        PetId: Ptr[int64](198772),
+        Quantity: Ptr[int32](7),
+        ShipDate: Ptr[time.Time](time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)),
+        Status: Ptr[OrderStatus]("approved"),
+    }
+}
+
+// OrderInterface defines the interface for Order
+type OrderInterface interface {
+    Validate() error
+    // Add any additional interface methods here
+}
+
+// Ensure Order implements OrderInterface
+var _ OrderInterface = (*Order)(nil)
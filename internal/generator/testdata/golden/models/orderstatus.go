@@ -0,0 +1,60 @@
+package models
+
+import (
+    "encoding/json"
+    "fmt"
+)
+
+// OrderStatus Order Status
+type OrderStatus string
+
+// Known OrderStatus values
+const (
+    OrderStatusPlaced OrderStatus = "placed"
+    OrderStatusApproved OrderStatus = "approved"
+    OrderStatusDelivered OrderStatus = "delivered"
+)
+
+// Values returns all known OrderStatus values
+func (e OrderStatus) Values() []OrderStatus {
+    return []OrderStatus{
+        OrderStatusPlaced,
+        OrderStatusApproved,
+        OrderStatusDelivered,
+    }
+}
+
+// IsValid reports whether e is one of the known OrderStatus values
+func (e OrderStatus) IsValid() bool {
+    switch e {
+    case OrderStatusPlaced, OrderStatusApproved, OrderStatusDelivered:
+        return true
+    }
+    return false
+}
+
+// UnmarshalJSON implements json.Unmarshaler and rejects unknown values
+func (e *OrderStatus) UnmarshalJSON(data []byte) error {
+    if string(data) == "null" {
+        return nil
+    }
+
+    var v string
+    if err := json.Unmarshal(data, &v); err != nil {
+        return err
+    }
+    if !OrderStatus(v).IsValid() {
+        return fmt.Errorf("invalid OrderStatus value %v", v)
+    }
+
+    *e = OrderStatus(v)
+    return nil
+}
+
+// Validate checks that e is one of the known OrderStatus values
+func (e OrderStatus) Validate() error {
+    if !e.IsValid() {
+        return fmt.Errorf("invalid OrderStatus value %v", string(e))
+    }
+    return nil
+}
@@ -0,0 +1,60 @@
+package models
+
+type Pet struct {
+    Category *Category `json:"category,omitempty"`
+    Id *int64 `json:"id,omitempty"`
+    Name string `json:"name" validate:"required"`
+    PhotoUrls []string `json:"photoUrls" validate:"required"`
+    // pet status in the store
+    Status *PetStatus `json:"status,omitempty" validate:"oneof=available pending sold"`
+    Tags []Tag `json:"tags,omitempty"`
+}
+
+// Validate checks if the Pet satisfies all constraints and reports
+// every violation it finds
+func (m *Pet) Validate() error {
+    var errs FieldErrors
+    if m.Category != nil {
+        if err := m.Category.Validate(); err != nil {
+            errs.Merge("category", err)
+        }
+    }
+    if m.Name == "" {
+        errs.Add("name", "is required")
+    }
+    if m.PhotoUrls == nil {
+        errs.Add("photoUrls", "is required")
+    }
+    if m.Status != nil {
+        if err := m.Status.Validate(); err != nil {
+            errs.Merge("status", err)
+        }
+    }
+    for i := range m.Tags {
+        if err := m.Tags[i].Validate(); err != nil {
+            errs.Merge(indexPath("tags", i), err)
+        }
+    }
+    return errs.Err()
+}
+
+// ExamplePet returns an example instance of Pet
+func ExamplePet() *Pet {
+    return &Pet{
+        Category: nil,
+        Id: Ptr[int64](10),
+        Name: "doggie",
+        PhotoUrls: nil,
+        Status: Ptr[PetStatus]("available"),
+        Tags: nil,
+    }
+}
+
+// PetInterface defines the interface for Pet
+type PetInterface interface {
+    Validate() error
+    // Add any additional interface methods here
+}
+
+// Ensure Pet implements PetInterface
+var _ PetInterface = (*Pet)(nil)
@@ -0,0 +1,60 @@
+package models
+
+import (
+    "encoding/json"
+    "fmt"
+)
+
+// PetStatus pet status in the store
+type PetStatus string
+
+// Known PetStatus values
+const (
+    PetStatusAvailable PetStatus = "available"
+    PetStatusPending PetStatus = "pending"
+    PetStatusSold PetStatus = "sold"
+)
+
+// Values returns all known PetStatus values
+func (e PetStatus) Values() []PetStatus {
+    return []PetStatus{
+        PetStatusAvailable,
+        PetStatusPending,
+        PetStatusSold,
+    }
+}
+
+// IsValid reports whether e is one of the known PetStatus values
+func (e PetStatus) IsValid() bool {
+    switch e {
+    case PetStatusAvailable, PetStatusPending, PetStatusSold:
+        return true
+    }
+    return false
+}
+
+// UnmarshalJSON implements json.Unmarshaler and rejects unknown values
+func (e *PetStatus) UnmarshalJSON(data []byte) error {
+    if string(data) == "null" {
+        return nil
+    }
+
+    var v string
+    if err := json.Unmarshal(data, &v); err != nil {
+        return err
+    }
+    if !PetStatus(v).IsValid() {
+        return fmt.Errorf("invalid PetStatus value %v", v)
+    }
+
+    *e = PetStatus(v)
+    return nil
+}
+
+// Validate checks that e is one of the known PetStatus values
+func (e PetStatus) Validate() error {
+    if !e.IsValid() {
+        return fmt.Errorf("invalid PetStatus value %v", string(e))
+    }
+    return nil
+}
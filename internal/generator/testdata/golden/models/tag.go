@@ -0,0 +1,30 @@
+package models
+
+type Tag struct {
+    Id *int64 `json:"id,omitempty"`
+    Name *string `json:"name,omitempty"`
+}
+
+// Validate checks if the Tag satisfies all constraints and reports
+// every violation it finds
+func (m *Tag) Validate() error {
+    var errs FieldErrors
+    return errs.Err()
+}
+
+// ExampleTag returns an example instance of Tag
+func ExampleTag() *Tag {
+    return &Tag{
+        Id: Ptr[int64](1),
+        Name: Ptr[string]("example"),
+    }
+}
+
+// TagInterface defines the interface for Tag
+type TagInterface interface {
+    Validate() error
+    // Add any additional interface methods here
+}
+
+// Ensure Tag implements TagInterface
+var _ TagInterface = (*Tag)(nil)
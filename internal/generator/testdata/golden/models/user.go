@@ -0,0 +1,43 @@
+package models
+
+type User struct {
+    Email *string `json:"email,omitempty"`
+    FirstName *string `json:"firstName,omitempty"`
+    Id *int64 `json:"id,omitempty"`
+    LastName *string `json:"lastName,omitempty"`
+    Password *string `json:"password,omitempty"`
+    Phone *string `json:"phone,omitempty"`
+    // User Status
+    UserStatus *int32 `json:"userStatus,omitempty"`
+    Username *string `json:"username,omitempty"`
+}
+
+// Validate checks if the User satisfies all constraints and reports
+// every violation it finds
+func (m *User) Validate() error {
+    var errs FieldErrors
+    return errs.Err()
+}
+
+// ExampleUser returns an example instance of User
+func ExampleUser() *User {
+    return &User{
+        Email: Ptr[string]("john@email.com"),
+        FirstName: Ptr[string]("John"),
+        Id: Ptr[int64](10),
+        LastName: Ptr[string]("James"),
+        Password: Ptr[string]("12345"),
+        Phone: Ptr[string]("12345"),
+        UserStatus: Ptr[int32](1),
+        Username: Ptr[string]("theUser"),
+    }
+}
+
+// UserInterface defines the interface for User
+type UserInterface interface {
+    Validate() error
+    // Add any additional interface methods here
+}
+
+// Ensure User implements UserInterface
+var _ UserInterface = (*User)(nil)
@@ -0,0 +1,102 @@
+package models
+
+import (
+    "encoding/json"
+    "errors"
+    "math"
+    "strconv"
+    "strings"
+)
+
+// FieldError describes a single constraint violated by a field
+type FieldError struct {
+    Field   string
+    Message string
+}
+
+func (e FieldError) Error() string {
+    if e.Field == "" {
+        return e.Message
+    }
+    return e.Field + ": " + e.Message
+}
+
+// FieldErrors collects every constraint violation found by Validate
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+    msgs := make([]string, len(e))
+    for i, err := range e {
+        msgs[i] = err.Error()
+    }
+    return "validation failed: " + strings.Join(msgs, "; ")
+}
+
+// Add records a violation of field
+func (e *FieldErrors) Add(field, message string) {
+    *e = append(*e, FieldError{Field: field, Message: message})
+}
+
+// Merge records the violations reported by a nested value under field
+func (e *FieldErrors) Merge(field string, err error) {
+    var nested FieldErrors
+    if !errors.As(err, &nested) {
+        e.Add(field, err.Error())
+        return
+    }
+    for _, n := range nested {
+        e.Add(joinPath(field, n.Field), n.Message)
+    }
+}
+
+// Err returns the collected violations, or nil if there are none
+func (e FieldErrors) Err() error {
+    if len(e) == 0 {
+        return nil
+    }
+    return e
+}
+
+func joinPath(prefix, field string) string {
+    switch {
+    case prefix == "":
+        return field
+    case field == "":
+        return prefix
+    case strings.HasPrefix(field, "["):
+        return prefix + field
+    default:
+        return prefix + "." + field
+    }
+}
+
+func indexPath(field string, i int) string {
+    return field + "[" + strconv.Itoa(i) + "]"
+}
+
+func keyPath(field, key string) string {
+    return field + "[" + strconv.Quote(key) + "]"
+}
+
+// isMultipleOf reports whether v is a multiple of n, allowing for floating
+// point rounding
+func isMultipleOf(v, n float64) bool {
+    q := v / n
+    return math.Abs(q-math.Round(q)) < 1e-9
+}
+
+// hasUniqueItems reports whether no two items have the same JSON encoding
+func hasUniqueItems[T any](items []T) bool {
+    seen := make(map[string]bool, len(items))
+    for _, item := range items {
+        data, err := json.Marshal(item)
+        if err != nil {
+            return false
+        }
+        if seen[string(data)] {
+            return false
+        }
+        seen[string(data)] = true
+    }
+    return true
+}
@@ -0,0 +1,302 @@
+package myapi
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "io"
+    "net/http"
+    "net/url"
+    "sort"
+    "strings"
+    "sync"
+    "time"
+)
+
+// DefaultExpiryDelta is how long before their expiry cached tokens are
+// refreshed, so that a token does not expire while a request is in flight
+const DefaultExpiryDelta = 30 * time.Second
+
+type scopesKey struct{}
+
+// ContextWithScopes returns a context carrying the OAuth2 scopes a request
+// needs. The client sets them from each operation's security requirement.
+func ContextWithScopes(ctx context.Context, scopes ...string) context.Context {
+    if len(scopes) == 0 {
+        return ctx
+    }
+    return context.WithValue(ctx, scopesKey{}, scopes)
+}
+
+// ScopesFromContext returns the OAuth2 scopes set with ContextWithScopes, for
+// TokenSource implementations that request tokens per scope set
+func ScopesFromContext(ctx context.Context) []string {
+    scopes, _ := ctx.Value(scopesKey{}).([]string)
+    return scopes
+}
+
+// OAuth2Token is an access token issued by an OAuth2 token endpoint
+type OAuth2Token struct {
+    AccessToken  string `json:"access_token"`
+    TokenType    string `json:"token_type,omitempty"`
+    RefreshToken string `json:"refresh_token,omitempty"`
+    ExpiresIn    int64  `json:"expires_in,omitempty"`
+    Scope        string `json:"scope,omitempty"`
+
+    // Expiry is when the token expires, computed from ExpiresIn. It is zero
+    // for tokens that do not expire.
+    Expiry time.Time `json:"-"`
+}
+
+// valid reports whether the token can still be used for at least delta
+func (t *OAuth2Token) valid(delta time.Duration) bool {
+    if t == nil || t.AccessToken == "" {
+        return false
+    }
+    return t.Expiry.IsZero() || time.Now().Add(delta).Before(t.Expiry)
+}
+
+// OAuth2Error is returned when a token endpoint rejects a request
+type OAuth2Error struct {
+    StatusCode  int
+    Code        string `json:"error"`
+    Description string `json:"error_description"`
+}
+
+// Error implements the error interface
+func (e *OAuth2Error) Error() string {
+    msg := fmt.Sprintf("token endpoint returned status %d", e.StatusCode)
+    if e.Code != "" {
+        msg += ": " + e.Code
+    }
+    if e.Description != "" {
+        msg += ": " + e.Description
+    }
+    return msg
+}
+
+// ClientCredentialsSource is a TokenSource that obtains access tokens with
+// the OAuth2 client credentials grant. Tokens are cached per set of scopes and
+// requested again ExpiryDelta before they expire. The fields must not be
+// changed once the source is in use.
+type ClientCredentialsSource struct {
+    TokenURL     string
+    ClientID     string
+    ClientSecret string
+
+    // Scopes are requested with every token, in addition to the scopes of the
+    // operation being called
+    Scopes []string
+
+    // AuthInBody sends the client credentials as form parameters instead of
+    // with HTTP basic authentication
+    AuthInBody bool
+
+    // HTTPClient is used for token requests; http.DefaultClient if nil
+    HTTPClient *http.Client
+
+    // ExpiryDelta overrides DefaultExpiryDelta when positive
+    ExpiryDelta time.Duration
+
+    cache tokenCache
+}
+
+// NewClientCredentialsSource returns a ClientCredentialsSource for the given
+// token endpoint
+func NewClientCredentialsSource(tokenURL, clientID, clientSecret string, scopes ...string) *ClientCredentialsSource {
+    return &ClientCredentialsSource{
+        TokenURL:     tokenURL,
+        ClientID:     clientID,
+        ClientSecret: clientSecret,
+        Scopes:       scopes,
+    }
+}
+
+// Token implements TokenSource
+func (s *ClientCredentialsSource) Token(ctx context.Context) (string, error) {
+    scopes := mergeScopes(s.Scopes, ScopesFromContext(ctx))
+    token, err := s.cache.get(strings.Join(scopes, " "), s.ExpiryDelta, func() (*OAuth2Token, error) {
+        form := url.Values{"grant_type": {"client_credentials"}}
+        if len(scopes) > 0 {
+            form.Set("scope", strings.Join(scopes, " "))
+        }
+        return requestToken(ctx, s.HTTPClient, s.TokenURL, s.ClientID, s.ClientSecret, s.AuthInBody, form)
+    })
+    if err != nil {
+        return "", err
+    }
+    return token.AccessToken, nil
+}
+
+// RefreshTokenSource is a TokenSource that obtains access tokens with the
+// OAuth2 refresh token grant, e.g. from a refresh token issued by an
+// authorization code flow. Tokens are cached per set of scopes and refreshed
+// ExpiryDelta before they expire. When the endpoint rotates the refresh token
+// the new one is used from then on. The fields must not be changed once the
+// source is in use.
+type RefreshTokenSource struct {
+    TokenURL     string
+    ClientID     string
+    ClientSecret string
+    RefreshToken string
+
+    // Scopes are requested with every token, in addition to the scopes of the
+    // operation being called. Without any the scopes originally granted are
+    // kept.
+    Scopes []string
+
+    // AuthInBody sends the client credentials as form parameters instead of
+    // with HTTP basic authentication
+    AuthInBody bool
+
+    // HTTPClient is used for token requests; http.DefaultClient if nil
+    HTTPClient *http.Client
+
+    // ExpiryDelta overrides DefaultExpiryDelta when positive
+    ExpiryDelta time.Duration
+
+    // OnRefresh is called with every token obtained, e.g. to persist a
+    // rotated refresh token
+    OnRefresh func(*OAuth2Token)
+
+    cache tokenCache
+}
+
+// NewRefreshTokenSource returns a RefreshTokenSource for the given token
+// endpoint and refresh token
+func NewRefreshTokenSource(tokenURL, clientID, clientSecret, refreshToken string) *RefreshTokenSource {
+    return &RefreshTokenSource{
+        TokenURL:     tokenURL,
+        ClientID:     clientID,
+        ClientSecret: clientSecret,
+        RefreshToken: refreshToken,
+    }
+}
+
+// Token implements TokenSource
+func (s *RefreshTokenSource) Token(ctx context.Context) (string, error) {
+    scopes := mergeScopes(s.Scopes, ScopesFromContext(ctx))
+    token, err := s.cache.get(strings.Join(scopes, " "), s.ExpiryDelta, func() (*OAuth2Token, error) {
+        // Called with the cache locked, which also guards RefreshToken
+        form := url.Values{
+            "grant_type":    {"refresh_token"},
+            "refresh_token": {s.RefreshToken},
+        }
+        if len(scopes) > 0 {
+            form.Set("scope", strings.Join(scopes, " "))
+        }
+        token, err := requestToken(ctx, s.HTTPClient, s.TokenURL, s.ClientID, s.ClientSecret, s.AuthInBody, form)
+        if err != nil {
+            return nil, err
+        }
+        if token.RefreshToken != "" {
+            s.RefreshToken = token.RefreshToken
+        }
+        if s.OnRefresh != nil {
+            s.OnRefresh(token)
+        }
+        return token, nil
+    })
+    if err != nil {
+        return "", err
+    }
+    return token.AccessToken, nil
+}
+
+// tokenCache holds the tokens of a source keyed by scope set
+type tokenCache struct {
+    mu     sync.Mutex
+    tokens map[string]*OAuth2Token
+}
+
+// get returns the cached token for key, calling fetch if there is none or it
+// expires within delta. The lock is held while fetching so that concurrent
+// requests share one token request.
+func (c *tokenCache) get(key string, delta time.Duration, fetch func() (*OAuth2Token, error)) (*OAuth2Token, error) {
+    if delta <= 0 {
+        delta = DefaultExpiryDelta
+    }
+
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    if token := c.tokens[key]; token.valid(delta) {
+        return token, nil
+    }
+
+    token, err := fetch()
+    if err != nil {
+        return nil, err
+    }
+    if c.tokens == nil {
+        c.tokens = make(map[string]*OAuth2Token)
+    }
+    c.tokens[key] = token
+    return token, nil
+}
+
+// requestToken posts a token request to tokenURL and decodes the response
+func requestToken(ctx context.Context, client *http.Client, tokenURL, clientID, clientSecret string, authInBody bool, form url.Values) (*OAuth2Token, error) {
+    if authInBody {
+        form.Set("client_id", clientID)
+        if clientSecret != "" {
+            form.Set("client_secret", clientSecret)
+        }
+    }
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+    if err != nil {
+        return nil, fmt.Errorf("failed to create token request: %w", err)
+    }
+    req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+    req.Header.Set("Accept", "application/json")
+    if !authInBody {
+        req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+    }
+
+    if client == nil {
+        client = http.DefaultClient
+    }
+    resp, err := client.Do(req)
+    if err != nil {
+        return nil, fmt.Errorf("token request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+    if err != nil {
+        return nil, fmt.Errorf("failed to read token response: %w", err)
+    }
+
+    if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+        oauthErr := &OAuth2Error{StatusCode: resp.StatusCode}
+        _ = json.Unmarshal(body, oauthErr)
+        return nil, oauthErr
+    }
+
+    var token OAuth2Token
+    if err := json.Unmarshal(body, &token); err != nil {
+        return nil, fmt.Errorf("failed to decode token response: %w", err)
+    }
+    if token.AccessToken == "" {
+        return nil, fmt.Errorf("token response has no access_token")
+    }
+    if token.ExpiresIn > 0 {
+        token.Expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+    }
+    return &token, nil
+}
+
+// mergeScopes returns the sorted union of two scope lists
+func mergeScopes(a, b []string) []string {
+    seen := make(map[string]bool, len(a)+len(b))
+    var scopes []string
+    for _, scope := range append(append([]string{}, a...), b...) {
+        if !seen[scope] {
+            seen[scope] = true
+            scopes = append(scopes, scope)
+        }
+    }
+    sort.Strings(scopes)
+    return scopes
+}
@@ -0,0 +1,137 @@
+package myapi_test
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+
+    myapi "petstore-sdk"
+)
+
+// tokenServer returns a token endpoint that issues numbered tokens and lets
+// check inspect each token request
+func tokenServer(t *testing.T, expiresIn int, check func(*testing.T, *http.Request)) (*httptest.Server, *int32) {
+    var issued int32
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        require.NoError(t, r.ParseForm())
+        if check != nil {
+            check(t, r)
+        }
+        n := atomic.AddInt32(&issued, 1)
+        w.Header().Set("Content-Type", "application/json")
+        err := json.NewEncoder(w).Encode(map[string]interface{}{
+            "access_token":  fmt.Sprintf("token-%d", n),
+            "token_type":    "Bearer",
+            "expires_in":    expiresIn,
+            "refresh_token": fmt.Sprintf("refresh-%d", n),
+        })
+        require.NoError(t, err)
+    }))
+    t.Cleanup(server.Close)
+    return server, &issued
+}
+
+func TestClientCredentialsSource(t *testing.T) {
+    server, issued := tokenServer(t, 3600, func(t *testing.T, r *http.Request) {
+        assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+        id, secret, ok := r.BasicAuth()
+        assert.True(t, ok)
+        assert.Equal(t, "id", id)
+        assert.Equal(t, "secret", secret)
+    })
+
+    source := myapi.NewClientCredentialsSource(server.URL, "id", "secret")
+    ctx := myapi.ContextWithScopes(context.Background(), "write", "read")
+
+    token, err := source.Token(ctx)
+    require.NoError(t, err)
+    assert.Equal(t, "token-1", token)
+
+    // Cached until expiry
+    token, err = source.Token(ctx)
+    require.NoError(t, err)
+    assert.Equal(t, "token-1", token)
+    assert.Equal(t, int32(1), atomic.LoadInt32(issued))
+
+    // Other scopes need their own token
+    token, err = source.Token(myapi.ContextWithScopes(context.Background(), "read"))
+    require.NoError(t, err)
+    assert.Equal(t, "token-2", token)
+}
+
+func TestClientCredentialsSourceScopes(t *testing.T) {
+    server, _ := tokenServer(t, 3600, func(t *testing.T, r *http.Request) {
+        assert.Equal(t, "admin read write", r.PostForm.Get("scope"))
+        assert.Equal(t, "id", r.PostForm.Get("client_id"))
+        assert.Equal(t, "secret", r.PostForm.Get("client_secret"))
+    })
+
+    source := myapi.NewClientCredentialsSource(server.URL, "id", "secret", "admin")
+    source.AuthInBody = true
+
+    _, err := source.Token(myapi.ContextWithScopes(context.Background(), "write", "read"))
+    require.NoError(t, err)
+}
+
+func TestClientCredentialsSourceRefreshesBeforeExpiry(t *testing.T) {
+    server, issued := tokenServer(t, 10, nil)
+
+    source := myapi.NewClientCredentialsSource(server.URL, "id", "secret")
+    source.ExpiryDelta = time.Minute
+
+    for i := 0; i < 3; i++ {
+        _, err := source.Token(context.Background())
+        require.NoError(t, err)
+    }
+    assert.Equal(t, int32(3), atomic.LoadInt32(issued))
+}
+
+func TestRefreshTokenSource(t *testing.T) {
+    var sent []string
+    server, _ := tokenServer(t, 10, func(t *testing.T, r *http.Request) {
+        assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+        sent = append(sent, r.PostForm.Get("refresh_token"))
+    })
+
+    var refreshed []string
+    source := myapi.NewRefreshTokenSource(server.URL, "id", "secret", "initial")
+    source.ExpiryDelta = time.Minute
+    source.OnRefresh = func(token *myapi.OAuth2Token) {
+        refreshed = append(refreshed, token.RefreshToken)
+    }
+
+    for i := 0; i < 2; i++ {
+        _, err := source.Token(context.Background())
+        require.NoError(t, err)
+    }
+
+    // The rotated refresh token is used for the next request
+    assert.Equal(t, []string{"initial", "refresh-1"}, sent)
+    assert.Equal(t, []string{"refresh-1", "refresh-2"}, refreshed)
+}
+
+func TestTokenEndpointError(t *testing.T) {
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.Header().Set("Content-Type", "application/json")
+        w.WriteHeader(http.StatusUnauthorized)
+        _, _ = w.Write([]byte(`{"error":"invalid_client","error_description":"unknown client"}`))
+    }))
+    defer server.Close()
+
+    source := myapi.NewClientCredentialsSource(server.URL, "id", "wrong")
+    _, err := source.Token(context.Background())
+
+    var oauthErr *myapi.OAuth2Error
+    require.True(t, errors.As(err, &oauthErr))
+    assert.Equal(t, http.StatusUnauthorized, oauthErr.StatusCode)
+    assert.Equal(t, "invalid_client", oauthErr.Code)
+}
@@ -0,0 +1,320 @@
+package myapi
+
+import (
+    "encoding"
+    "fmt"
+    "net/http"
+    "reflect"
+    "sort"
+    "strconv"
+    "strings"
+)
+
+// paramValue is a parameter value broken down the way OpenAPI serializes
+// it: a primitive, an array of primitives or an object of primitive
+// properties
+type paramValue struct {
+    kind   paramKind
+    values []string
+    keys   []string // property names of an object, in the order of values
+}
+
+type paramKind int
+
+const (
+    paramPrimitive paramKind = iota
+    paramArray
+    paramObject
+)
+
+// toParamValue breaks v down, reporting false if it is unset: a nil
+// pointer, or an empty array or object
+func toParamValue(v interface{}) (paramValue, bool) {
+    rv, ok := indirect(reflect.ValueOf(v))
+    if !ok {
+        return paramValue{}, false
+    }
+    if _, ok := rv.Interface().(encoding.TextMarshaler); ok {
+        s, ok := primitive(rv)
+        return paramValue{values: []string{s}}, ok
+    }
+
+    switch rv.Kind() {
+    case reflect.Slice, reflect.Array:
+        p := paramValue{kind: paramArray}
+        for i := 0; i < rv.Len(); i++ {
+            if s, ok := primitive(rv.Index(i)); ok {
+                p.values = append(p.values, s)
+            }
+        }
+        return p, len(p.values) > 0
+    case reflect.Map:
+        p := paramValue{kind: paramObject}
+        keys := rv.MapKeys()
+        sort.Slice(keys, func(i, j int) bool {
+            return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+        })
+        for _, key := range keys {
+            if s, ok := primitive(rv.MapIndex(key)); ok {
+                p.keys = append(p.keys, fmt.Sprint(key.Interface()))
+                p.values = append(p.values, s)
+            }
+        }
+        return p, len(p.values) > 0
+    case reflect.Struct:
+        // Properties keep the order of the struct's fields
+        p := paramValue{kind: paramObject}
+        for i := 0; i < rv.NumField(); i++ {
+            field := rv.Type().Field(i)
+            name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+            if !field.IsExported() || name == "-" {
+                continue
+            }
+            if name == "" {
+                name = field.Name
+            }
+            if s, ok := primitive(rv.Field(i)); ok {
+                p.keys = append(p.keys, name)
+                p.values = append(p.values, s)
+            }
+        }
+        return p, len(p.values) > 0
+    }
+
+    s, ok := primitive(rv)
+    return paramValue{values: []string{s}}, ok
+}
+
+// indirect follows pointers and interfaces, reporting false if one is nil
+func indirect(rv reflect.Value) (reflect.Value, bool) {
+    for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+        if rv.IsNil() {
+            return rv, false
+        }
+        rv = rv.Elem()
+    }
+    return rv, rv.IsValid()
+}
+
+// primitive formats a primitive value, reporting false if it is unset.
+// Times and other text marshalers are formatted as their text.
+func primitive(rv reflect.Value) (string, bool) {
+    rv, ok := indirect(rv)
+    if !ok {
+        return "", false
+    }
+    if m, ok := rv.Interface().(encoding.TextMarshaler); ok {
+        text, err := m.MarshalText()
+        return string(text), err == nil
+    }
+
+    switch rv.Kind() {
+    case reflect.String:
+        return rv.String(), true
+    case reflect.Bool:
+        return strconv.FormatBool(rv.Bool()), true
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        return strconv.FormatInt(rv.Int(), 10), true
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+        return strconv.FormatUint(rv.Uint(), 10), true
+    case reflect.Float32:
+        return strconv.FormatFloat(rv.Float(), 'f', -1, 32), true
+    case reflect.Float64:
+        return strconv.FormatFloat(rv.Float(), 'f', -1, 64), true
+    }
+    return fmt.Sprint(rv.Interface()), true
+}
+
+// escaped returns p with its keys and values percent-encoded
+func (p paramValue) escaped(allowReserved bool) paramValue {
+    escapeAll := func(values []string) []string {
+        escaped := make([]string, len(values))
+        for i, v := range values {
+            escaped[i] = escape(v, allowReserved)
+        }
+        return escaped
+    }
+    return paramValue{kind: p.kind, values: escapeAll(p.values), keys: escapeAll(p.keys)}
+}
+
+// list returns the values of p, interleaved with the keys of an object
+func (p paramValue) list() []string {
+    if p.kind != paramObject {
+        return p.values
+    }
+    list := make([]string, 0, 2*len(p.values))
+    for i, v := range p.values {
+        list = append(list, p.keys[i], v)
+    }
+    return list
+}
+
+// pairs returns the key=value pairs of an object, prefixing keys with
+// prefix and wrapping them in brackets if deep
+func (p paramValue) pairs(prefix string, deep bool) []string {
+    pairs := make([]string, len(p.values))
+    for i, v := range p.values {
+        if deep {
+            pairs[i] = prefix + "[" + p.keys[i] + "]=" + v
+        } else {
+            pairs[i] = prefix + p.keys[i] + "=" + v
+        }
+    }
+    return pairs
+}
+
+// serializeParam serializes a parameter named name in one of the styles of
+// OpenAPI. Keys and values of p must already be escaped as needed.
+func serializeParam(name string, p paramValue, style string, explode bool) string {
+    explode = explode && p.kind != paramPrimitive
+    list := strings.Join(p.list(), ",")
+
+    switch style {
+    case "simple":
+        if explode && p.kind == paramObject {
+            return strings.Join(p.pairs("", false), ",")
+        }
+        return list
+    case "label":
+        switch {
+        case !explode:
+            return "." + list
+        case p.kind == paramObject:
+            return "." + strings.Join(p.pairs("", false), ".")
+        }
+        return "." + strings.Join(p.values, ".")
+    case "matrix":
+        switch {
+        case !explode && p.kind == paramPrimitive && p.values[0] == "":
+            return ";" + name
+        case !explode:
+            return ";" + name + "=" + list
+        case p.kind == paramObject:
+            return ";" + strings.Join(p.pairs("", false), ";")
+        }
+        return ";" + name + "=" + strings.Join(p.values, ";"+name+"=")
+    case "spaceDelimited", "pipeDelimited":
+        if !explode && p.kind != paramPrimitive {
+            sep := "%20"
+            if style == "pipeDelimited" {
+                sep = "|"
+            }
+            return name + "=" + strings.Join(p.list(), sep)
+        }
+    case "deepObject":
+        if p.kind == paramObject {
+            return strings.Join(p.pairs(name, true), "&")
+        }
+    }
+
+    // form
+    switch {
+    case !explode:
+        return name + "=" + list
+    case p.kind == paramObject:
+        return strings.Join(p.pairs("", false), "&")
+    }
+    return name + "=" + strings.Join(p.values, "&"+name+"=")
+}
+
+// escape percent-encodes s for a URL. Unreserved characters are kept, and
+// so are reserved ones if allowReserved, except for # which would end the
+// URL.
+func escape(s string, allowReserved bool) string {
+    var b strings.Builder
+    for i := 0; i < len(s); i++ {
+        c := s[i]
+        switch {
+        case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9',
+            c == '-', c == '.', c == '_', c == '~':
+            b.WriteByte(c)
+        case allowReserved && c != '#' && strings.IndexByte(":/?[]@!$&'()*+,;=", c) >= 0:
+            b.WriteByte(c)
+        default:
+            fmt.Fprintf(&b, "%%%02X", c)
+        }
+    }
+    return b.String()
+}
+
+// pathParam is a parameter substituted into the path of a request
+type pathParam struct {
+    name    string
+    value   interface{}
+    style   string // simple, label or matrix
+    explode bool
+}
+
+// buildPath substitutes the parameters of a path template such as
+// /pets/{petId}
+func buildPath(template string, params ...pathParam) (string, error) {
+    for _, param := range params {
+        p, ok := toParamValue(param.value)
+        if !ok {
+            return "", fmt.Errorf("path parameter %s is required", param.name)
+        }
+        s := serializeParam(escape(param.name, false), p.escaped(false), param.style, param.explode)
+
+        // Dot segments would be removed from the path
+        if s == "." || s == ".." {
+            s = strings.ReplaceAll(s, ".", "%2E")
+        }
+        template = strings.ReplaceAll(template, "{"+param.name+"}", s)
+    }
+    return template, nil
+}
+
+// queryParams builds a query string in the order parameters are added
+type queryParams []string
+
+// add serializes a query parameter in the form, spaceDelimited,
+// pipeDelimited or deepObject style, unless it is unset
+func (q *queryParams) add(name string, v interface{}, style string, explode, allowReserved bool) {
+    p, ok := toParamValue(v)
+    if !ok {
+        return
+    }
+    *q = append(*q, serializeParam(escape(name, false), p.escaped(allowReserved), style, explode))
+}
+
+func (q queryParams) encode() string {
+    return strings.Join(q, "&")
+}
+
+// headerParam serializes a header parameter in the simple style, reporting
+// false if it is unset
+func headerParam(v interface{}, explode bool) (string, bool) {
+    p, ok := toParamValue(v)
+    if !ok {
+        return "", false
+    }
+    return serializeParam("", p, "simple", explode), true
+}
+
+// addCookieParam adds a cookie parameter in the form style to the Cookie
+// header of req, unless it is unset. Exploded arrays and objects become a
+// cookie per value.
+func addCookieParam(req *http.Request, name string, v interface{}, explode bool) {
+    p, ok := toParamValue(v)
+    if !ok {
+        return
+    }
+    p = p.escaped(false)
+
+    var cookies []string
+    switch {
+    case !explode || p.kind == paramPrimitive:
+        cookies = []string{name + "=" + strings.Join(p.list(), ",")}
+    case p.kind == paramObject:
+        cookies = p.pairs("", false)
+    default:
+        for _, v := range p.values {
+            cookies = append(cookies, name+"="+v)
+        }
+    }
+
+    if existing := req.Header.Get("Cookie"); existing != "" {
+        cookies = append([]string{existing}, cookies...)
+    }
+    req.Header.Set("Cookie", strings.Join(cookies, "; "))
+}
@@ -0,0 +1,110 @@
+package myapi
+
+import (
+    "net/http"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type rgb struct {
+    R int `json:"R"`
+    G int `json:"G"`
+    B int `json:"B"`
+}
+
+// TestSerializeParam checks the style examples of the OpenAPI
+// specification. "-" marks combinations the specification leaves undefined.
+func TestSerializeParam(t *testing.T) {
+    values := []interface{}{"", "blue", []string{"blue", "black", "brown"}, rgb{R: 100, G: 200, B: 150}}
+
+    tests := []struct {
+        style   string
+        explode bool
+        want    []string // empty, string, array and object
+    }{
+        {"matrix", false, []string{";color", ";color=blue", ";color=blue,black,brown", ";color=R,100,G,200,B,150"}},
+        {"matrix", true, []string{";color", ";color=blue", ";color=blue;color=black;color=brown", ";R=100;G=200;B=150"}},
+        {"label", false, []string{".", ".blue", ".blue,black,brown", ".R,100,G,200,B,150"}},
+        {"label", true, []string{".", ".blue", ".blue.black.brown", ".R=100.G=200.B=150"}},
+        {"form", false, []string{"color=", "color=blue", "color=blue,black,brown", "color=R,100,G,200,B,150"}},
+        {"form", true, []string{"color=", "color=blue", "color=blue&color=black&color=brown", "R=100&G=200&B=150"}},
+        {"simple", false, []string{"", "blue", "blue,black,brown", "R,100,G,200,B,150"}},
+        {"simple", true, []string{"", "blue", "blue,black,brown", "R=100,G=200,B=150"}},
+        {"spaceDelimited", false, []string{"-", "-", "color=blue%20black%20brown", "color=R%20100%20G%20200%20B%20150"}},
+        {"pipeDelimited", false, []string{"-", "-", "color=blue|black|brown", "color=R|100|G|200|B|150"}},
+        {"deepObject", true, []string{"-", "-", "-", "color[R]=100&color[G]=200&color[B]=150"}},
+    }
+
+    for _, tt := range tests {
+        for i, want := range tt.want {
+            if want == "-" {
+                continue
+            }
+            p, ok := toParamValue(values[i])
+            require.True(t, ok)
+            got := serializeParam("color", p.escaped(false), tt.style, tt.explode)
+            assert.Equal(t, want, got, "style=%s explode=%v value=%v", tt.style, tt.explode, values[i])
+        }
+    }
+}
+
+func TestBuildPath(t *testing.T) {
+    tests := []struct {
+        name  string
+        param pathParam
+        want  string
+    }{
+        {"escapes values", pathParam{name: "id", value: "a/b c", style: "simple"}, "/files/a%2Fb%20c"},
+        {"escapes dot segments", pathParam{name: "id", value: "..", style: "simple"}, "/files/%2E%2E"},
+        {"formats numbers", pathParam{name: "id", value: int64(42), style: "simple"}, "/files/42"},
+        {"matrix array", pathParam{name: "id", value: []int{1, 2}, style: "matrix", explode: true}, "/files/;id=1;id=2"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := buildPath("/files/{id}", tt.param)
+            require.NoError(t, err)
+            assert.Equal(t, tt.want, got)
+        })
+    }
+
+    var unset *string
+    _, err := buildPath("/files/{id}", pathParam{name: "id", value: unset, style: "simple"})
+    assert.Error(t, err)
+}
+
+func TestQueryParams(t *testing.T) {
+    var unset *int
+    limit := 10
+    at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+    var query queryParams
+    query.add("limit", &limit, "form", true, false)
+    query.add("offset", unset, "form", true, false)
+    query.add("tags", []string{}, "form", true, false)
+    query.add("q", "a b&c", "form", true, false)
+    query.add("path", "a/b?c", "form", true, true)
+    query.add("since", at, "form", true, false)
+    query.add("filter", map[string]string{"status": "sold", "name": "rex"}, "deepObject", true, false)
+
+    assert.Equal(t, "limit=10&q=a%20b%26c&path=a/b?c&since=2024-01-02T03%3A04%3A05Z&filter[name]=rex&filter[status]=sold", query.encode())
+}
+
+func TestHeaderAndCookieParams(t *testing.T) {
+    v, ok := headerParam([]string{"a", "b"}, false)
+    assert.True(t, ok)
+    assert.Equal(t, "a,b", v)
+
+    _, ok = headerParam((*string)(nil), false)
+    assert.False(t, ok)
+
+    req, err := http.NewRequest(http.MethodGet, "http://example.com", nil)
+    require.NoError(t, err)
+    addCookieParam(req, "session", "abc", true)
+    addCookieParam(req, "ids", []int{1, 2}, false)
+    addCookieParam(req, "id", []int{3, 4}, true)
+    assert.Equal(t, "session=abc; ids=1,2; id=3; id=4", req.Header.Get("Cookie"))
+}
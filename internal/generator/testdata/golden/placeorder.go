@@ -0,0 +1,97 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "io"
+    "net/http"
+    "petstore-sdk/models"
+)
+// Placeorder Place a new order in the store
+
+// PlaceorderRequest contains the request body for Placeorder,
+// sent as application/json
+type PlaceorderRequest struct {
+    Body models.Order
+}
+
+// encode returns the encoded request body and its content type
+func (r *PlaceorderRequest) encode() (io.Reader, string, error) {
+    return jsonBody(&r.Body, "application/json")
+}
+
+// PlaceorderResponse is the response of Placeorder. At most one
+// of the typed bodies is set, depending on the status code.
+type PlaceorderResponse struct {
+    StatusCode int
+    Header     http.Header
+    // JSON200 is the body of a 200 response: successful operation
+    JSON200 *models.Order
+}
+
+// Placeorder Place a new order in the store
+func (c *Client) Placeorder(
+    ctx context.Context,
+    request *PlaceorderRequest,
+) (*PlaceorderResponse, error) {
+
+    path := "/store/order"
+
+    // Encode request body
+    var body io.Reader
+    var contentType string
+    if request != nil {
+        if err := beforeSend(request); err != nil {
+            return nil, err
+        }
+        var err error
+        body, contentType, err = request.encode()
+        if err != nil {
+            return nil, fmt.Errorf("failed to encode request body: %w", err)
+        }
+    }
+
+    // Create request
+    req, err := c.newRequest(ctx, "POST", path, body, contentType)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    // Send request
+    op := operation{
+        name:       "Placeorder",
+        tags:       []string{"store"},
+        idempotent: false,
+    }
+    resp, err := c.do(req, op)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    // Decode the body documented for the status code. Error statuses are
+    // returned as an *APIError carrying the decoded error model.
+    response := &PlaceorderResponse{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+    switch {
+    case resp.StatusCode == 200:
+        var v models.Order
+        if err := decodeJSON(resp, &v); err != nil {
+            return nil, err
+        }
+        response.JSON200 = &v
+    case resp.StatusCode == 400:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode == 422:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode >= 400:
+        return nil, newAPIError(resp, nil)
+    }
+
+    if err := afterDecode(response); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
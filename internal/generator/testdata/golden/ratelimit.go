@@ -0,0 +1,83 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+
+    "golang.org/x/time/rate"
+)
+
+// rateLimits holds the token buckets requests wait for before being sent.
+// A request waits for the client-wide bucket, the buckets of its operation's
+// tags and the bucket of the operation itself.
+type rateLimits struct {
+    global     *rate.Limiter
+    tags       map[string]*rate.Limiter
+    operations map[string]*rate.Limiter
+}
+
+// newRateLimits returns the buckets declared in the API description with
+// the x-rate-limit extension
+func newRateLimits() rateLimits {
+    return rateLimits{
+        tags: map[string]*rate.Limiter{
+        },
+        operations: map[string]*rate.Limiter{
+        },
+    }
+}
+
+// WithRateLimit limits all requests of the client to requestsPerSecond,
+// allowing bursts of up to burst requests. A requestsPerSecond of zero
+// removes the limit.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+    return func(c *Client) {
+        c.rateLimits.global = newLimiter(requestsPerSecond, burst)
+    }
+}
+
+// WithTagRateLimit limits the requests of the operations with the given tag,
+// which share one bucket. A requestsPerSecond of zero removes the limit.
+func WithTagRateLimit(tag string, requestsPerSecond float64, burst int) ClientOption {
+    return func(c *Client) {
+        c.rateLimits.tags[tag] = newLimiter(requestsPerSecond, burst)
+    }
+}
+
+// WithOperationRateLimit limits the requests of one operation, named after
+// its client method. A requestsPerSecond of zero removes the limit.
+func WithOperationRateLimit(operation string, requestsPerSecond float64, burst int) ClientOption {
+    return func(c *Client) {
+        c.rateLimits.operations[operation] = newLimiter(requestsPerSecond, burst)
+    }
+}
+
+func newLimiter(requestsPerSecond float64, burst int) *rate.Limiter {
+    if requestsPerSecond <= 0 {
+        return nil
+    }
+    if burst < 1 {
+        burst = 1
+    }
+    return rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+}
+
+// waitRateLimits blocks until every bucket that applies to op allows a
+// request, or ctx is done
+func (c *Client) waitRateLimits(ctx context.Context, op operation) error {
+    limiters := []*rate.Limiter{c.rateLimits.global}
+    for _, tag := range op.tags {
+        limiters = append(limiters, c.rateLimits.tags[tag])
+    }
+    limiters = append(limiters, c.rateLimits.operations[op.name])
+
+    for _, limiter := range limiters {
+        if limiter == nil {
+            continue
+        }
+        if err := limiter.Wait(ctx); err != nil {
+            return fmt.Errorf("rate limit: %w", err)
+        }
+    }
+    return nil
+}
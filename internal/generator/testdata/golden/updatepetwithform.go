@@ -0,0 +1,99 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+)
+
+// UpdatepetwithformParams contains the parameters for Updatepetwithform
+type UpdatepetwithformParams struct {
+    // ID of pet that needs to be updated
+    PetId int64 `json:"petId" validate:"required"`
+    // Name of pet that needs to be updated
+    Name *string `json:"name,omitempty"`
+    // Status of pet that needs to be updated
+    Status *string `json:"status,omitempty"`
+}
+
+// UpdatepetwithformResponse is the response of Updatepetwithform. At most one
+// of the typed bodies is set, depending on the status code.
+type UpdatepetwithformResponse struct {
+    StatusCode int
+    Header     http.Header
+}
+
+// Updatepetwithform 
+func (c *Client) Updatepetwithform(
+    ctx context.Context,
+    params *UpdatepetwithformParams,
+) (*UpdatepetwithformResponse, error) {
+    if params == nil {
+        return nil, fmt.Errorf("params cannot be nil")
+    }
+    if err := params.validate(); err != nil {
+        return nil, err
+    }
+
+    // Build path with path parameters
+    path, err := buildPath("/pet/{petId}",
+        pathParam{name: "petId", value: params.PetId, style: "simple", explode: false},
+    )
+    if err != nil {
+        return nil, err
+    }
+
+    // Add query parameters
+    var query queryParams
+    query.add("name", params.Name, "form", true, false)
+    query.add("status", params.Status, "form", true, false)
+    if len(query) > 0 {
+        path += "?" + query.encode()
+    }
+
+    // Create request
+    req, err := c.newRequest(ctx, "POST", path, nil, "")
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    // Apply the credentials of the first satisfiable security requirement
+    if err := c.authenticate(req, [][]securityRequirement{{{"petstore_auth", []string{"write:pets", "read:pets"}}}}); err != nil {
+        return nil, err
+    }
+
+    // Send request
+    op := operation{
+        name:       "Updatepetwithform",
+        tags:       []string{"pet"},
+        idempotent: false,
+    }
+    resp, err := c.do(req, op)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    // Decode the body documented for the status code. Error statuses are
+    // returned as an *APIError carrying the decoded error model.
+    response := &UpdatepetwithformResponse{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+    switch {
+    case resp.StatusCode == 400:
+        return nil, newAPIError(resp, nil)
+    case resp.StatusCode >= 400:
+        return nil, newAPIError(resp, nil)
+    }
+
+    if err := afterDecode(response); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
+
+// validate checks that the required parameters are set
+func (p *UpdatepetwithformParams) validate() error {
+    return nil
+}
@@ -0,0 +1,112 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "io"
+    "net/http"
+    "petstore-sdk/models"
+)
+// Updateuser This can only be done by the logged in user.
+
+// UpdateuserParams contains the parameters for Updateuser
+type UpdateuserParams struct {
+    // name that need to be deleted
+    Username string `json:"username" validate:"required"`
+}
+
+// UpdateuserRequest contains the request body for Updateuser,
+// sent as application/json
+type UpdateuserRequest struct {
+    // Update an existent user in the store
+    Body models.User
+}
+
+// encode returns the encoded request body and its content type
+func (r *UpdateuserRequest) encode() (io.Reader, string, error) {
+    return jsonBody(&r.Body, "application/json")
+}
+
+// UpdateuserResponse is the response of Updateuser. At most one
+// of the typed bodies is set, depending on the status code.
+type UpdateuserResponse struct {
+    StatusCode int
+    Header     http.Header
+}
+
+// Updateuser This can only be done by the logged in user.
+func (c *Client) Updateuser(
+    ctx context.Context,
+    params *UpdateuserParams,
+    request *UpdateuserRequest,
+) (*UpdateuserResponse, error) {
+    if params == nil {
+        return nil, fmt.Errorf("params cannot be nil")
+    }
+    if err := params.validate(); err != nil {
+        return nil, err
+    }
+
+    // Build path with path parameters
+    path, err := buildPath("/user/{username}",
+        pathParam{name: "username", value: params.Username, style: "simple", explode: false},
+    )
+    if err != nil {
+        return nil, err
+    }
+
+    // Encode request body
+    var body io.Reader
+    var contentType string
+    if request != nil {
+        if err := beforeSend(request); err != nil {
+            return nil, err
+        }
+        var err error
+        body, contentType, err = request.encode()
+        if err != nil {
+            return nil, fmt.Errorf("failed to encode request body: %w", err)
+        }
+    }
+
+    // Create request
+    req, err := c.newRequest(ctx, "PUT", path, body, contentType)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    // Send request
+    op := operation{
+        name:       "Updateuser",
+        tags:       []string{"user"},
+        idempotent: true,
+    }
+    resp, err := c.do(req, op)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    // Decode the body documented for the status code. Error statuses are
+    // returned as an *APIError carrying the decoded error model.
+    response := &UpdateuserResponse{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+    switch {
+    default:
+        if resp.StatusCode >= 400 {
+            return nil, newAPIError(resp, nil)
+        }
+    }
+
+    if err := afterDecode(response); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
+
+// validate checks that the required parameters are set
+func (p *UpdateuserParams) validate() error {
+    return nil
+}
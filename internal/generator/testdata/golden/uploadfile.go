@@ -0,0 +1,135 @@
+package myapi
+
+import (
+    "context"
+    "fmt"
+    "io"
+    "net/http"
+    "petstore-sdk/models"
+)
+
+// UploadfileParams contains the parameters for Uploadfile
+type UploadfileParams struct {
+    // ID of pet to update
+    PetId int64 `json:"petId" validate:"required"`
+    // Additional Metadata
+    AdditionalMetadata *string `json:"additionalMetadata,omitempty"`
+}
+
+// UploadfileRequest contains the request body for Uploadfile,
+// sent as application/octet-stream
+type UploadfileRequest struct {
+    Body io.Reader
+    // ContentType is sent instead of application/octet-stream if set
+    ContentType string
+}
+
+// encode returns the encoded request body and its content type
+func (r *UploadfileRequest) encode() (io.Reader, string, error) {
+    if r.ContentType != "" {
+        return r.Body, r.ContentType, nil
+    }
+    return r.Body, "application/octet-stream", nil
+}
+
+// UploadfileResponse is the response of Uploadfile. At most one
+// of the typed bodies is set, depending on the status code.
+type UploadfileResponse struct {
+    StatusCode int
+    Header     http.Header
+    // JSON200 is the body of a 200 response: successful operation
+    JSON200 *models.ApiResponse
+}
+
+// Uploadfile 
+func (c *Client) Uploadfile(
+    ctx context.Context,
+    params *UploadfileParams,
+    request *UploadfileRequest,
+) (*UploadfileResponse, error) {
+    if params == nil {
+        return nil, fmt.Errorf("params cannot be nil")
+    }
+    if err := params.validate(); err != nil {
+        return nil, err
+    }
+
+    // Build path with path parameters
+    path, err := buildPath("/pet/{petId}/uploadImage",
+        pathParam{name: "petId", value: params.PetId, style: "simple", explode: false},
+    )
+    if err != nil {
+        return nil, err
+    }
+
+    // Add query parameters
+    var query queryParams
+    query.add("additionalMetadata", params.AdditionalMetadata, "form", true, false)
+    if len(query) > 0 {
+        path += "?" + query.encode()
+    }
+
+    // Encode request body
+    var body io.Reader
+    var contentType string
+    if request != nil {
+        if err := beforeSend(request); err != nil {
+            return nil, err
+        }
+        var err error
+        body, contentType, err = request.encode()
+        if err != nil {
+            return nil, fmt.Errorf("failed to encode request body: %w", err)
+        }
+    }
+
+    // Create request
+    req, err := c.newRequest(ctx, "POST", path, body, contentType)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    // Apply the credentials of the first satisfiable security requirement
+    if err := c.authenticate(req, [][]securityRequirement{{{"petstore_auth", []string{"write:pets", "read:pets"}}}}); err != nil {
+        return nil, err
+    }
+
+    // Send request
+    op := operation{
+        name:       "Uploadfile",
+        tags:       []string{"pet"},
+        idempotent: false,
+    }
+    resp, err := c.do(req, op)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    // Decode the body documented for the status code. Error statuses are
+    // returned as an *APIError carrying the decoded error model.
+    response := &UploadfileResponse{
+        StatusCode: resp.StatusCode,
+        Header:     resp.Header,
+    }
+    switch {
+    case resp.StatusCode == 200:
+        var v models.ApiResponse
+        if err := decodeJSON(resp, &v); err != nil {
+            return nil, err
+        }
+        response.JSON200 = &v
+    case resp.StatusCode >= 400:
+        return nil, newAPIError(resp, nil)
+    }
+
+    if err := afterDecode(response); err != nil {
+        return nil, err
+    }
+    return response, nil
+}
+
+// validate checks that the required parameters are set
+func (p *UploadfileParams) validate() error {
+    return nil
+}
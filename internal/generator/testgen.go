@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/chashtager/opensdkraft/internal/config"
 	"github.com/chashtager/opensdkraft/internal/parser"
-	"github.com/chashtager/opensdkraft/internal/utils"
 	"path/filepath"
 	"strings"
 )
@@ -13,6 +12,8 @@ type TestGenerator struct {
 	config    *config.Config
 	templates *TemplateEngine
 	parser    *parser.Parser
+	sources   *sourceMap
+	output    *outputWriter
 }
 
 type OperationTestData struct {
@@ -29,13 +30,34 @@ func NewTestGenerator(config *config.Config, templates *TemplateEngine, parser *
 	}
 }
 
-func (g *TestGenerator) Generate(operations []*Operation) error {
+func (g *TestGenerator) Generate(operations []*Operation, schemes []SecurityScheme) error {
 	for _, op := range operations {
+		if !hasExamples(op) {
+			continue
+		}
 		if err := g.generateOperationTest(op, g.config.OutputDir); err != nil {
 			return fmt.Errorf("failed to generate tests for operation %s: %w", op.Name, err)
 		}
 	}
 
+	if hasOAuth2(schemes) {
+		if err := g.generateOAuth2Test(); err != nil {
+			return fmt.Errorf("failed to generate OAuth2 tests: %w", err)
+		}
+	}
+
+	if hasParameters(operations) {
+		if err := g.generateParamsTest(queryAPIKey(schemes)); err != nil {
+			return fmt.Errorf("failed to generate parameter tests: %w", err)
+		}
+	}
+
+	if hasStreams(operations) {
+		if err := g.generateStreamTest(); err != nil {
+			return fmt.Errorf("failed to generate stream tests: %w", err)
+		}
+	}
+
 	// Generate test helpers
 	if err := g.generateTestHelpers(); err != nil {
 		return fmt.Errorf("failed to generate test helpers: %w", err)
@@ -44,6 +66,19 @@ func (g *TestGenerator) Generate(operations []*Operation) error {
 	return nil
 }
 
+// hasExamples reports whether values could be made up for the required
+// parameters of an operation, which its test needs to call it
+func hasExamples(op *Operation) bool {
+	for _, param := range op.Parameters {
+		if param.Required && param.ExampleValue == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// generateOperationTest writes the test of an operation next to it, in the
+// same package
 func (g *TestGenerator) generateOperationTest(operation *Operation, outputDir string) error {
 	data := &OperationTestData{
 		Operation:   operation,
@@ -56,10 +91,11 @@ func (g *TestGenerator) generateOperationTest(operation *Operation, outputDir st
 		return fmt.Errorf("failed to generate test for operation %s: %w", operation.Name, err)
 	}
 
-	filename := filepath.Join(outputDir, "tests", strings.ToLower(operation.Name)+"_test.go")
-	if err := utils.WriteFile(filename, content); err != nil {
+	filename := filepath.Join(outputDir, strings.ToLower(operation.Name)+"_test.go")
+	if err := g.output.write(filename, content); err != nil {
 		return fmt.Errorf("failed to write test file for operation %s: %w", operation.Name, err)
 	}
+	g.sources.add(filename, operation.Source.Pointer)
 
 	return nil
 }
@@ -78,6 +114,65 @@ func (g *TestGenerator) generateTestHelpers() error {
 		return err
 	}
 
-	filename := filepath.Join(g.config.OutputDir, "tests", "helpers_test.go")
-	return utils.WriteFile(filename, content)
+	filename := filepath.Join(g.config.OutputDir, "helpers_test.go")
+	return g.output.write(filename, content)
+}
+
+func (g *TestGenerator) generateOAuth2Test() error {
+	data := struct {
+		PackageName string
+		Config      *config.Config
+	}{
+		PackageName: g.config.PackageName,
+		Config:      g.config,
+	}
+
+	content, err := g.templates.Execute("oauth2_test", data)
+	if err != nil {
+		return err
+	}
+
+	filename := filepath.Join(g.config.OutputDir, "oauth2_test.go")
+	return g.output.write(filename, content)
+}
+
+func (g *TestGenerator) generateStreamTest() error {
+	data := struct {
+		PackageName string
+		Config      *config.Config
+	}{
+		PackageName: g.config.PackageName,
+		Config:      g.config,
+	}
+
+	content, err := g.templates.Execute("stream_test", data)
+	if err != nil {
+		return err
+	}
+
+	filename := filepath.Join(g.config.OutputDir, "stream_test.go")
+	return g.output.write(filename, content)
+}
+
+// generateParamsTest writes the tests of the parameter serialization next
+// to it, since they exercise unexported code. An API key sent in the query
+// is tested along with the parameters it is appended to.
+func (g *TestGenerator) generateParamsTest(apiKey *SecurityScheme) error {
+	data := struct {
+		PackageName string
+		APIKey      *SecurityScheme
+		Config      *config.Config
+	}{
+		PackageName: g.config.PackageName,
+		APIKey:      apiKey,
+		Config:      g.config,
+	}
+
+	content, err := g.templates.Execute("params_test", data)
+	if err != nil {
+		return err
+	}
+
+	filename := filepath.Join(g.config.OutputDir, "params_test.go")
+	return g.output.write(filename, content)
 }
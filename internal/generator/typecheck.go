@@ -0,0 +1,293 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/chashtager/opensdkraft/internal/errors"
+	"github.com/chashtager/opensdkraft/internal/utils"
+)
+
+// dependencyStubs declare the API of the third-party packages imported by
+// generated code, so that the SDK can be type-checked without downloading
+// them. They only need what the templates use.
+var dependencyStubs = map[string]string{
+	"golang.org/x/time/rate": `package rate
+
+import "context"
+
+type Limit float64
+
+type Limiter struct{}
+
+func NewLimiter(r Limit, b int) *Limiter { return &Limiter{} }
+
+func (lim *Limiter) Wait(ctx context.Context) error { return nil }
+`,
+	"github.com/stretchr/testify/assert": `package assert
+
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+func Contains(t TestingT, s, contains interface{}, msgAndArgs ...interface{}) bool { return true }
+
+func Empty(t TestingT, object interface{}, msgAndArgs ...interface{}) bool { return true }
+
+func Equal(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool { return true }
+
+func Error(t TestingT, err error, msgAndArgs ...interface{}) bool { return true }
+
+func False(t TestingT, value bool, msgAndArgs ...interface{}) bool { return true }
+
+func NotContains(t TestingT, s, contains interface{}, msgAndArgs ...interface{}) bool { return true }
+
+func True(t TestingT, value bool, msgAndArgs ...interface{}) bool { return true }
+`,
+	"github.com/stretchr/testify/require": `package require
+
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+	FailNow()
+}
+
+func Equal(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {}
+
+func Error(t TestingT, err error, msgAndArgs ...interface{}) {}
+
+func NoError(t TestingT, err error, msgAndArgs ...interface{}) {}
+
+func NotNil(t TestingT, object interface{}, msgAndArgs ...interface{}) {}
+
+func True(t TestingT, value bool, msgAndArgs ...interface{}) {}
+`,
+}
+
+// errTypeCheckUnavailable reports that the standard library could not be
+// imported, e.g. because no Go toolchain is installed
+var errTypeCheckUnavailable = errors.New(errors.ErrCodeValidationFailed,
+	"type check unavailable")
+
+// typeChecker type-checks the packages of a generated SDK the way go build
+// would, and then their tests the way go vet would
+type typeChecker struct {
+	fset   *token.FileSet
+	module string
+	dir    string
+	std    types.Importer
+
+	packages map[string]*types.Package // nil while being checked
+	files    map[string][]*ast.File    // of the packages, without tests
+	errors   map[string][]string       // by file, relative to dir
+}
+
+func newTypeChecker(module, dir string) *typeChecker {
+	return &typeChecker{
+		fset:     token.NewFileSet(),
+		module:   module,
+		dir:      dir,
+		std:      importer.Default(),
+		packages: make(map[string]*types.Package),
+		files:    make(map[string][]*ast.File),
+		errors:   make(map[string][]string),
+	}
+}
+
+// typeCheck type-checks every package of the generated SDK, so that a spec
+// the templates cannot express fails the generation instead of producing an
+// SDK that does not compile
+func (g *Generator) typeCheck() error {
+	checker := newTypeChecker(g.config.Module, g.config.OutputDir)
+	if _, err := checker.std.Import("context"); err != nil {
+		return fmt.Errorf("%w: %v", errTypeCheckUnavailable, err)
+	}
+
+	// Every directory with Go files other than tests is a package
+	packages := make(map[string]bool)
+	err := filepath.WalkDir(g.config.OutputDir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if isPackageFile(d) {
+			rel, err := filepath.Rel(g.config.OutputDir, filepath.Dir(p))
+			if err != nil {
+				return err
+			}
+			packages[path.Join(g.config.Module, filepath.ToSlash(rel))] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.FileSystemError(err)
+	}
+
+	for _, pkg := range utils.SortedKeys(packages) {
+		if _, err := checker.Import(pkg); err != nil {
+			return err
+		}
+	}
+	for _, pkg := range utils.SortedKeys(packages) {
+		if err := checker.checkTests(pkg); err != nil {
+			return err
+		}
+	}
+
+	var typeErrors ValidationErrors
+	g.reportCodeIssues(&typeErrors, "Types", checker.errors)
+	if len(typeErrors.Errors) > 0 {
+		return &typeErrors
+	}
+	return nil
+}
+
+// Import implements types.Importer, checking packages of the SDK from
+// source
+func (c *typeChecker) Import(importPath string) (*types.Package, error) {
+	if pkg, ok := c.packages[importPath]; ok {
+		if pkg == nil {
+			return nil, fmt.Errorf("import cycle through %s", importPath)
+		}
+		return pkg, nil
+	}
+
+	if stub, ok := dependencyStubs[importPath]; ok {
+		file, err := parser.ParseFile(c.fset, importPath+"/stub.go", stub, 0)
+		if err != nil {
+			return nil, err
+		}
+		return c.check(importPath, []*ast.File{file}, false), nil
+	}
+
+	rel, ok := c.relativePath(importPath)
+	if !ok {
+		return c.std.Import(importPath)
+	}
+
+	files, err := c.parseDir(filepath.Join(c.dir, filepath.FromSlash(rel)), importPath, isPackageFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in package %s", importPath)
+	}
+	c.files[importPath] = files
+	return c.check(importPath, files, false), nil
+}
+
+// checkTests type-checks the tests of a package of the SDK. Tests in the
+// package are checked along with its files, tests in the external _test
+// package on their own.
+func (c *typeChecker) checkTests(importPath string) error {
+	rel, _ := c.relativePath(importPath)
+	files, err := c.parseDir(filepath.Join(c.dir, filepath.FromSlash(rel)), importPath, isTestFile)
+	if err != nil {
+		return err
+	}
+
+	internal := append([]*ast.File(nil), c.files[importPath]...)
+	var external []*ast.File
+	for _, file := range files {
+		if strings.HasSuffix(file.Name.Name, "_test") {
+			external = append(external, file)
+		} else {
+			internal = append(internal, file)
+		}
+	}
+
+	if len(internal) > len(c.files[importPath]) {
+		c.check(importPath, internal, true)
+	}
+	if len(external) > 0 {
+		c.check(importPath+"_test", external, true)
+	}
+	return nil
+}
+
+// relativePath returns the path of a package of the SDK relative to the
+// module root, reporting false for other packages
+func (c *typeChecker) relativePath(importPath string) (string, bool) {
+	if importPath == c.module {
+		return ".", true
+	}
+	rel, ok := strings.CutPrefix(importPath, c.module+"/")
+	return rel, ok
+}
+
+// parseDir parses the Go files of a directory that include selects
+func (c *typeChecker) parseDir(dir, importPath string, include func(os.DirEntry) bool) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("package %s: %w", importPath, err)
+	}
+
+	var files []*ast.File
+	for _, entry := range entries {
+		if !include(entry) {
+			continue
+		}
+		filename := filepath.Join(dir, entry.Name())
+		file, err := parser.ParseFile(c.fset, filename, nil, 0)
+		if err != nil {
+			c.report(filename, err.Error())
+			continue
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// isPackageFile reports whether a directory entry is a Go file built into
+// its package, i.e. not a test
+func isPackageFile(entry os.DirEntry) bool {
+	name := entry.Name()
+	return !entry.IsDir() && filepath.Ext(name) == ".go" && !strings.HasSuffix(name, "_test.go")
+}
+
+// isTestFile reports whether a directory entry is a Go test file
+func isTestFile(entry os.DirEntry) bool {
+	return !entry.IsDir() && strings.HasSuffix(entry.Name(), "_test.go")
+}
+
+// check type-checks a package, collecting its errors. The package of
+// tests is not importable, and only the errors in its test files are new.
+func (c *typeChecker) check(importPath string, files []*ast.File, tests bool) *types.Package {
+	if !tests {
+		c.packages[importPath] = nil
+	}
+
+	conf := types.Config{
+		Importer: c,
+		Error: func(err error) {
+			if typeErr, ok := err.(types.Error); ok {
+				filename := typeErr.Fset.Position(typeErr.Pos).Filename
+				if !tests || strings.HasSuffix(filename, "_test.go") {
+					c.report(filename, err.Error())
+				}
+			}
+		},
+	}
+	// The package is complete enough for importers despite errors, which
+	// have been collected by conf.Error
+	pkg, _ := conf.Check(importPath, c.fset, files, nil)
+
+	if !tests {
+		c.packages[importPath] = pkg
+	}
+	return pkg
+}
+
+// report records a problem in a file, shortening file names to paths
+// within the SDK
+func (c *typeChecker) report(filename, msg string) {
+	prefix := filepath.Clean(c.dir) + string(filepath.Separator)
+	filename = filepath.ToSlash(strings.TrimPrefix(filename, prefix))
+	c.errors[filename] = append(c.errors[filename], strings.ReplaceAll(msg, prefix, ""))
+}
@@ -0,0 +1,221 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/chashtager/opensdkraft/internal/config"
+	"github.com/chashtager/opensdkraft/internal/errors"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// UnionData is the template data for a oneOf/anyOf wrapper type
+type UnionData struct {
+	Name          string
+	PackageName   string
+	Description   string
+	AnyOf         bool
+	Members       []UnionMember
+	Discriminator *DiscriminatorData
+	Imports       []string
+	Config        *config.Config
+	Source        Source
+}
+
+// UnionMember is one of the alternatives a union can hold
+type UnionMember struct {
+	Accessor            string
+	Type                string
+	IsStruct            bool
+	DiscriminatorValues []string
+}
+
+// ValueType returns the Go type stored in the union for this member
+func (m UnionMember) ValueType() string {
+	if m.IsStruct {
+		return "*" + m.Type
+	}
+	return m.Type
+}
+
+// DiscriminatorData describes the property used to select a union member
+type DiscriminatorData struct {
+	PropertyName string
+}
+
+// isUnion reports whether the schema is a oneOf or anyOf composition
+func isUnion(schema *openapi3.Schema) bool {
+	return len(schema.OneOf) > 0 || len(schema.AnyOf) > 0
+}
+
+func (g *ModelGenerator) generateUnion(name, pointer string, schema *openapi3.SchemaRef) error {
+	unionData, err := g.prepareUnionData(name, schema)
+	if err != nil {
+		return &ValidationError{
+			Category: "Model",
+			Path:     name,
+			Source:   Source{Pointer: pointer},
+			Errors:   []string{err.Error()},
+		}
+	}
+	unionData.Source = g.sources.source(pointer)
+
+	content, err := g.templates.Execute("union", unionData)
+	if err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	filename := modelFile(g.config, name)
+	if err := g.output.write(filename, content); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+func (g *ModelGenerator) prepareUnionData(name string, schema *openapi3.SchemaRef) (*UnionData, error) {
+	if schema == nil || schema.Value == nil {
+		return nil, fmt.Errorf("invalid schema for %s", name)
+	}
+
+	members := schema.Value.OneOf
+	keyword, anyOf := "oneOf", false
+	if len(members) == 0 {
+		members = schema.Value.AnyOf
+		keyword, anyOf = "anyOf", true
+	}
+
+	unionData := &UnionData{
+		Name:        g.typeMapper.ToGoName(name),
+		PackageName: modelsPackage(g.config),
+		Description: schema.Value.Description,
+		AnyOf:       anyOf,
+		Config:      g.config,
+	}
+
+	mapping, err := g.discriminatorMapping(schema.Value.Discriminator, keyword, members)
+	if err != nil {
+		return nil, err
+	}
+	if schema.Value.Discriminator != nil {
+		unionData.Discriminator = &DiscriminatorData{
+			PropertyName: schema.Value.Discriminator.PropertyName,
+		}
+	}
+
+	seenImports := make(map[string]bool)
+	seenAccessors := make(map[string]bool)
+
+	for i, member := range members {
+		if member == nil || member.Value == nil {
+			return nil, fmt.Errorf("member %d of %s is nil", i, name)
+		}
+
+		goType, imports := g.typeMapper.ToGoTypeNamed(member, fmt.Sprintf("%sOption%d", unionData.Name, i+1))
+		accessor := unionAccessorName(goType)
+		if seenAccessors[accessor] {
+			return nil, fmt.Errorf("members of %s resolve to the same type %s", name, goType)
+		}
+		seenAccessors[accessor] = true
+
+		unionData.Members = append(unionData.Members, UnionMember{
+			Accessor:            accessor,
+			Type:                goType,
+			IsStruct:            g.typeMapper.IsStructType(member),
+			DiscriminatorValues: mapping[i],
+		})
+
+		for _, imp := range imports {
+			if !seenImports[imp] {
+				unionData.Imports = append(unionData.Imports, imp)
+				seenImports[imp] = true
+			}
+		}
+	}
+
+	// Unions without a discriminator fall back to decoding into each member
+	if unionData.Discriminator == nil {
+		unionData.Imports = append(unionData.Imports, "bytes")
+	}
+
+	return unionData, nil
+}
+
+// discriminatorMapping returns the discriminator values selecting each member,
+// by index. Explicit mapping entries take precedence; referenced members that
+// are not mapped are selected by their component name as the spec
+// prescribes, and inline members by the single value their enum allows for
+// the discriminator property.
+func (g *ModelGenerator) discriminatorMapping(discriminator *openapi3.Discriminator, keyword string, members openapi3.SchemaRefs) ([][]string, error) {
+	mapping := make([][]string, len(members))
+	if discriminator == nil {
+		return mapping, nil
+	}
+
+	byRef := make(map[string][]string)
+	values := make([]string, 0, len(discriminator.Mapping))
+	for value := range discriminator.Mapping {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+
+	for _, value := range values {
+		ref := discriminator.Mapping[value]
+		if !strings.Contains(ref, "/") {
+			ref = "#/components/schemas/" + ref
+		}
+		byRef[ref] = append(byRef[ref], value)
+	}
+
+	for i, member := range members {
+		if member == nil || member.Value == nil {
+			continue
+		}
+		if values, ok := byRef[member.Ref]; ok {
+			mapping[i] = values
+			continue
+		}
+		if refName := schemaRefName(member.Ref); refName != "" {
+			mapping[i] = []string{refName}
+			continue
+		}
+
+		value, ok := discriminatorValue(member.Value, discriminator.PropertyName)
+		if !ok {
+			return nil, errors.InvalidInput(fmt.Sprintf(
+				"inline member %s/%d needs a single-valued string enum on discriminator property %q",
+				keyword, i, discriminator.PropertyName))
+		}
+		mapping[i] = []string{value}
+	}
+
+	return mapping, nil
+}
+
+// discriminatorValue returns the only value an inline member allows for the
+// discriminator property, reporting false if it allows any other number
+func discriminatorValue(member *openapi3.Schema, propertyName string) (string, bool) {
+	property := member.Properties[propertyName]
+	if property == nil || property.Value == nil || len(property.Value.Enum) != 1 {
+		return "", false
+	}
+	value, ok := property.Value.Enum[0].(string)
+	return value, ok
+}
+
+// unionAccessorName derives the suffix of the As/From methods from a member's
+// Go type, e.g. "Cat" for Cat and "StringList" for []string.
+func unionAccessorName(goType string) string {
+	switch {
+	case strings.HasPrefix(goType, "[]"):
+		return unionAccessorName(strings.TrimPrefix(goType, "[]")) + "List"
+	case strings.HasPrefix(goType, "map["):
+		return "Map"
+	case goType == "interface{}":
+		return "Any"
+	case goType == "time.Time":
+		return "Time"
+	}
+	return strings.ToUpper(goType[:1]) + goType[1:]
+}
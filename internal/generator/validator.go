@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/chashtager/opensdkraft/internal/utils"
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
@@ -22,29 +23,30 @@ func (v *Validator) ValidateDocument(doc *openapi3.T) error {
 
 	// Validate required fields
 	if err := v.validateRequiredFields(doc); err != nil {
-		validationErrors.Add("Document", "root", err.Error())
+		validationErrors.AddSource("Document", "root", "#", err.Error())
 	}
 
 	// Validate paths
-	for path, pathItem := range doc.Paths.Map() {
-		if err := v.validatePathItem(path, pathItem); err != nil {
-			validationErrors.Add("Path", path, err.Error())
+	pathMap := doc.Paths.Map()
+	for _, path := range utils.SortedKeys(pathMap) {
+		if err := v.validatePathItem(path, pathMap[path]); err != nil {
+			validationErrors.AddSource("Path", path, jsonPointer("paths", path), err.Error())
 		}
 	}
 
 	// Validate components
 	if doc.Components != nil {
 		// Validate schemas
-		for name, schema := range doc.Components.Schemas {
-			if errs := v.validateSchema(schema); len(errs) > 0 {
-				validationErrors.Add("Schema", name, errs...)
+		for _, name := range utils.SortedKeys(doc.Components.Schemas) {
+			if errs := v.validateSchema(doc.Components.Schemas[name]); len(errs) > 0 {
+				validationErrors.AddSource("Schema", name, jsonPointer("components", "schemas", name), errs...)
 			}
 		}
 
 		// Validate responses
-		for name, response := range doc.Components.Responses {
-			if err := v.validateResponse(name, response); err != nil {
-				validationErrors.Add("Response", name, err.Error())
+		for _, name := range utils.SortedKeys(doc.Components.Responses) {
+			if err := v.validateResponse(name, doc.Components.Responses[name]); err != nil {
+				validationErrors.AddSource("Response", name, jsonPointer("components", "responses", name), err.Error())
 			}
 		}
 	}
@@ -62,18 +64,44 @@ func (v *Validator) validateSchema(schema *openapi3.SchemaRef) []string {
 		return []string{"schema is nil"}
 	}
 
-	// Type validation
-	if len(schema.Value.Type.Slice()) == 0 {
-		errors = append(errors, "missing type")
+	// Composed schemas take their type from their members
+	composed := false
+	for _, composition := range []struct {
+		keyword string
+		members openapi3.SchemaRefs
+	}{
+		{"allOf", schema.Value.AllOf},
+		{"oneOf", schema.Value.OneOf},
+		{"anyOf", schema.Value.AnyOf},
+	} {
+		for i, member := range composition.members {
+			composed = true
+			for _, err := range v.validateSchema(member) {
+				errors = append(errors, fmt.Sprintf("%s[%d]: %s", composition.keyword, i, err))
+			}
+		}
+	}
+
+	// Type validation; schemas declaring properties are implicitly objects
+	schemaType := ""
+	if types := schema.Value.Type.Slice(); len(types) > 0 {
+		schemaType = types[0]
+	} else if len(schema.Value.Properties) > 0 {
+		schemaType = "object"
+	} else if composed {
+		return errors
+	} else {
+		return append(errors, "missing type")
 	}
 
 	// Properties validation for objects
-	if schema.Value.Type.Slice()[0] == "object" {
-		if schema.Value.Properties == nil && schema.Value.AdditionalProperties.Schema == nil {
+	if schemaType == "object" {
+		if schema.Value.Properties == nil && schema.Value.AdditionalProperties.Schema == nil && !composed {
 			errors = append(errors, "object schema must define either properties or additionalProperties")
 		}
 
-		for propName, prop := range schema.Value.Properties {
+		for _, propName := range utils.SortedKeys(schema.Value.Properties) {
+			prop := schema.Value.Properties[propName]
 			if prop == nil || prop.Value == nil {
 				errors = append(errors, fmt.Sprintf("property %s is nil", propName))
 				continue
@@ -88,7 +116,7 @@ func (v *Validator) validateSchema(schema *openapi3.SchemaRef) []string {
 	}
 
 	// Array validation
-	if schema.Value.Type.Slice()[0] == "array" {
+	if schemaType == "array" {
 		if schema.Value.Items == nil {
 			errors = append(errors, "array schema must define items")
 		} else if itemErrors := v.validateSchema(schema.Value.Items); len(itemErrors) > 0 {
@@ -104,7 +132,8 @@ func (v *Validator) validateResponse(name string, response *openapi3.ResponseRef
 		return fmt.Errorf("response is nil")
 	}
 
-	for mediaType, content := range response.Value.Content {
+	for _, mediaType := range utils.SortedKeys(response.Value.Content) {
+		content := response.Value.Content[mediaType]
 		if content.Schema == nil {
 			return fmt.Errorf("missing schema for media type %s", mediaType)
 		}
@@ -143,7 +172,9 @@ func (v *Validator) validatePaths(paths *openapi3.Paths) error {
 	}
 
 	// Get all paths using methods from openapi3.Paths
-	for path, pathItem := range paths.Map() {
+	pathMap := paths.Map()
+	for _, path := range utils.SortedKeys(pathMap) {
+		pathItem := pathMap[path]
 		if pathItem == nil {
 			return fmt.Errorf("path %s has no operations", path)
 		}
@@ -211,7 +242,8 @@ func (v *Validator) validateComponents(components *openapi3.Components) error {
 	}
 
 	// Validate schemas
-	for name, schema := range components.Schemas {
+	for _, name := range utils.SortedKeys(components.Schemas) {
+		schema := components.Schemas[name]
 		if schema == nil || schema.Value == nil {
 			return fmt.Errorf("invalid schema definition for %s", name)
 		}
@@ -224,16 +256,30 @@ func (v *Validator) validateComponents(components *openapi3.Components) error {
 type ValidationError struct {
 	Category string   // e.g., "Model", "Operation", "Schema"
 	Path     string   // e.g., "Pet.name", "/pet/{petId}"
+	Source   Source   // element of the spec the errors are about, if known
 	Errors   []string // List of specific error messages
 }
 
 func (ve *ValidationError) Error() string {
 	return fmt.Sprintf("%s validation failed for %s:\n%s",
 		ve.Category,
-		ve.Path,
+		ve.subject(),
 		strings.Join(ve.Errors, "\n  - "))
 }
 
+// subject names what failed validation, with where it is declared in the
+// spec if known, e.g. "Pet at #/components/schemas/Pet (openapi.yaml:12:5)"
+func (ve *ValidationError) subject() string {
+	switch {
+	case ve.Source.Pointer == "":
+		return ve.Path
+	case ve.Source.Pointer == ve.Path:
+		return ve.Source.String()
+	default:
+		return ve.Path + " at " + ve.Source.String()
+	}
+}
+
 // ValidationErrors collects multiple validation errors
 type ValidationErrors struct {
 	Errors []ValidationError
@@ -248,9 +294,15 @@ func (ve *ValidationErrors) Error() string {
 }
 
 func (ve *ValidationErrors) Add(category, path string, errors ...string) {
+	ve.AddSource(category, path, "", errors...)
+}
+
+// AddSource adds errors about the element of the spec at pointer
+func (ve *ValidationErrors) AddSource(category, path, pointer string, errors ...string) {
 	ve.Errors = append(ve.Errors, ValidationError{
 		Category: category,
 		Path:     path,
+		Source:   Source{Pointer: pointer},
 		Errors:   errors,
 	})
 }
@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/chashtager/opensdkraft/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Position is a line and column in a spec file, both starting at 1
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Locations maps the JSON pointers of the elements of a spec file to where
+// they are declared in it
+type Locations struct {
+	file      string
+	positions map[string]Position
+}
+
+// LoadLocations indexes the elements of a YAML or JSON spec file by JSON
+// pointer. References to other files are not followed.
+func LoadLocations(filePath string) (*Locations, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, errors.FileSystemError(err)
+	}
+
+	// JSON is read as YAML, which it is a subset of
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, errors.ParsingFailed(fmt.Errorf("failed to index %s: %w", filePath, err))
+	}
+
+	l := &Locations{
+		file:      filePath,
+		positions: make(map[string]Position),
+	}
+	if len(root.Content) > 0 {
+		l.index("#", root.Content[0], root.Content[0])
+	}
+	return l, nil
+}
+
+// index records the position of the element at pointer, which is declared
+// by decl, e.g. its key in a mapping, and holds node
+func (l *Locations) index(pointer string, decl, node *yaml.Node) {
+	l.positions[pointer] = Position{Line: decl.Line, Column: decl.Column}
+
+	escape := strings.NewReplacer("~", "~0", "/", "~1")
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			l.index(pointer+"/"+escape.Replace(key.Value), key, value)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			l.index(pointer+"/"+strconv.Itoa(i), item, item)
+		}
+	}
+}
+
+// Lookup returns the position of the element at pointer. Elements the file
+// does not declare, such as defaults, are placed at their closest declared
+// ancestor.
+func (l *Locations) Lookup(pointer string) (Position, bool) {
+	if l == nil {
+		return Position{}, false
+	}
+
+	for {
+		if pos, ok := l.positions[pointer]; ok {
+			return pos, true
+		}
+		i := strings.LastIndex(pointer, "/")
+		if i < 0 {
+			return Position{}, false
+		}
+		pointer = pointer[:i]
+	}
+}
+
+// File returns the name of the indexed file
+func (l *Locations) File() string {
+	return l.file
+}
@@ -3,11 +3,13 @@ package parser
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/chashtager/opensdkraft/internal/errors"
+	"github.com/chashtager/opensdkraft/internal/utils"
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
@@ -66,8 +68,9 @@ func (p *Parser) Validate(doc *openapi3.T) error {
 func (p *Parser) GetOperations(doc *openapi3.T) ([]*Operation, error) {
 	var operations []*Operation
 
-	for path, pathItem := range doc.Paths.Map() {
-		pathOps, err := p.extractPathOperations(path, pathItem)
+	pathMap := doc.Paths.Map()
+	for _, path := range utils.SortedKeys(pathMap) {
+		pathOps, err := p.extractPathOperations(path, pathMap[path])
 		if err != nil {
 			return nil, err
 		}
@@ -113,20 +116,23 @@ type Response struct {
 	Description string
 }
 
+// httpMethods lists the operations of a path item in the order they are
+// extracted
+var httpMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodHead,
+	http.MethodOptions,
+}
+
 func (p *Parser) extractPathOperations(path string, pathItem *openapi3.PathItem) ([]*Operation, error) {
 	var operations []*Operation
 
-	methods := map[string]*openapi3.Operation{
-		"GET":     pathItem.Get,
-		"POST":    pathItem.Post,
-		"PUT":     pathItem.Put,
-		"DELETE":  pathItem.Delete,
-		"PATCH":   pathItem.Patch,
-		"HEAD":    pathItem.Head,
-		"OPTIONS": pathItem.Options,
-	}
-
-	for method, op := range methods {
+	for _, method := range httpMethods {
+		op := pathItem.GetOperation(method)
 		if op == nil {
 			continue
 		}
@@ -212,14 +218,7 @@ func (p *Parser) convertRequestBody(reqBody *openapi3.RequestBodyRef) (*RequestB
 		return nil, errors.InvalidInput("request body value is nil")
 	}
 
-	// Get the first content type (usually application/json)
-	var contentType string
-	var schema *openapi3.SchemaRef
-	for ct, content := range reqBody.Value.Content {
-		contentType = ct
-		schema = content.Schema
-		break
-	}
+	contentType, schema := firstContent(reqBody.Value.Content)
 
 	return &RequestBody{
 		Required:    reqBody.Value.Required,
@@ -234,14 +233,7 @@ func (p *Parser) convertResponse(status string, response *openapi3.ResponseRef)
 		return nil, errors.InvalidInput("response value is nil")
 	}
 
-	// Get the first content type (usually application/json)
-	var contentType string
-	var schema *openapi3.SchemaRef
-	for ct, content := range response.Value.Content {
-		contentType = ct
-		schema = content.Schema
-		break
-	}
+	contentType, schema := firstContent(response.Value.Content)
 
 	return &Response{
 		StatusCode:  status,
@@ -250,3 +242,15 @@ func (p *Parser) convertResponse(status string, response *openapi3.ResponseRef)
 		Description: *response.Value.Description,
 	}, nil
 }
+
+// firstContent returns the preferred content type and its schema: JSON if
+// present, otherwise the first content type in alphabetical order
+func firstContent(content openapi3.Content) (string, *openapi3.SchemaRef) {
+	if mt, ok := content["application/json"]; ok {
+		return "application/json", mt.Schema
+	}
+	for _, ct := range utils.SortedKeys(content) {
+		return ct, content[ct].Schema
+	}
+	return "", nil
+}
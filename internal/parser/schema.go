@@ -3,6 +3,7 @@ package parser
 import (
 	"fmt"
 
+	"github.com/chashtager/opensdkraft/internal/utils"
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
@@ -30,6 +31,7 @@ type SchemaProperty struct {
 	Format      string
 	Description string
 	Required    bool
+	Nullable    bool
 	IsPointer   bool
 	IsArray     bool
 	ItemSchema  *Schema
@@ -47,8 +49,8 @@ func (sp *SchemaParser) ParseSchemas() error {
 		return nil
 	}
 
-	for name, schemaRef := range sp.doc.Components.Schemas {
-		schema, err := sp.parseSchemaRef(name, schemaRef)
+	for _, name := range utils.SortedKeys(sp.doc.Components.Schemas) {
+		schema, err := sp.parseSchemaRef(name, sp.doc.Components.Schemas[name])
 		if err != nil {
 			return fmt.Errorf("failed to parse schema %s: %w", name, err)
 		}
@@ -82,8 +84,8 @@ func (sp *SchemaParser) parseSchemaRef(name string, schemaRef *openapi3.SchemaRe
 		schema.ItemSchema = itemSchema
 	}
 
-	for propName, propRef := range schemaRef.Value.Properties {
-		prop, err := sp.parseProperty(propName, propRef, schema.Required)
+	for _, propName := range utils.SortedKeys(schemaRef.Value.Properties) {
+		prop, err := sp.parseProperty(propName, schemaRef.Value.Properties[propName], schema.Required)
 		if err != nil {
 			return nil, err
 		}
@@ -105,7 +107,8 @@ func (sp *SchemaParser) parseProperty(name string, propRef *openapi3.SchemaRef,
 		Format:      propRef.Value.Format,
 		Description: propRef.Value.Description,
 		Required:    contains(required, name),
-		IsPointer:   !contains(required, name),
+		Nullable:    propRef.Value.Nullable,
+		IsPointer:   !contains(required, name) || propRef.Value.Nullable,
 	}
 
 	if prop.Type == "array" && propRef.Value.Items != nil {
@@ -136,12 +139,7 @@ func (sp *SchemaParser) determineImports(schema *Schema) []string {
 	}
 
 	// Convert map to slice
-	result := make([]string, 0, len(imports))
-	for imp := range imports {
-		result = append(result, imp)
-	}
-
-	return result
+	return utils.SortedKeys(imports)
 }
 
 func contains(slice []string, item string) bool {
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// numbered returns the lines 1 to n, replacing those in changed
+func numbered(n int, changed map[int]string) string {
+	var sb strings.Builder
+	for i := 1; i <= n; i++ {
+		if line, ok := changed[i]; ok {
+			if line != "" {
+				sb.WriteString(line + "\n")
+			}
+			continue
+		}
+		fmt.Fprintf(&sb, "%d\n", i)
+	}
+	return sb.String()
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{
+			name: "equal",
+			a:    "a\nb\nc\n",
+			b:    "a\nb\nc\n",
+			want: "",
+		},
+		{
+			name: "insert",
+			a:    "a\nb\nc\n",
+			b:    "a\nb\nx\nc\n",
+			want: "--- a\n+++ b\n@@ -1,3 +1,4 @@\n a\n b\n+x\n c\n",
+		},
+		{
+			name: "delete",
+			a:    "a\nb\nc\n",
+			b:    "a\nc\n",
+			want: "--- a\n+++ b\n@@ -1,3 +1,2 @@\n a\n-b\n c\n",
+		},
+		{
+			name: "insert into empty file",
+			a:    "",
+			b:    "x\n",
+			want: "--- a\n+++ b\n@@ -0,0 +1,1 @@\n+x\n",
+		},
+		{
+			name: "delete everything",
+			a:    "x\n",
+			b:    "",
+			want: "--- a\n+++ b\n@@ -1,1 +0,0 @@\n-x\n",
+		},
+		{
+			name: "changes more than 6 lines apart",
+			a:    numbered(20, nil),
+			b:    numbered(20, map[int]string{2: "two", 15: "fifteen"}),
+			want: "--- a\n+++ b\n" +
+				"@@ -1,5 +1,5 @@\n 1\n-2\n+two\n 3\n 4\n 5\n" +
+				"@@ -12,7 +12,7 @@\n 12\n 13\n 14\n-15\n+fifteen\n 16\n 17\n 18\n",
+		},
+		{
+			name: "changes 6 lines apart",
+			a:    numbered(12, nil),
+			b:    numbered(12, map[int]string{2: "two", 9: "nine"}),
+			want: "--- a\n+++ b\n" +
+				"@@ -1,12 +1,12 @@\n 1\n-2\n+two\n 3\n 4\n 5\n 6\n 7\n 8\n-9\n+nine\n 10\n 11\n 12\n",
+		},
+		{
+			name: "line numbers after a deletion",
+			a:    numbered(20, nil),
+			b:    numbered(20, map[int]string{2: "", 15: "fifteen"}),
+			want: "--- a\n+++ b\n" +
+				"@@ -1,5 +1,4 @@\n 1\n-2\n 3\n 4\n 5\n" +
+				"@@ -12,7 +11,7 @@\n 12\n 13\n 14\n-15\n+fifteen\n 16\n 17\n 18\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnifiedDiff("a", "b", tt.a, tt.b); got != tt.want {
+				t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package utils
+
+import "sort"
+
+// SortedKeys returns the keys of a string-keyed map in ascending order, so
+// that generated output does not depend on map iteration order
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,10 @@
+// Package templates holds the built-in code generation templates, embedded
+// so that the sdkraft binary works outside of the repository.
+package templates
+
+import "embed"
+
+// FS contains every built-in *.tmpl file
+//
+//go:embed *.tmpl
+var FS embed.FS